@@ -0,0 +1,342 @@
+package ucloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	// cassetteModeEnv is the environment variable to enable cassette mode,
+	// the available values are "record" and "replay".
+	cassetteModeEnv = "UCLOUD_CASSETTE"
+
+	// cassetteDirEnv is the environment variable of the directory to save cassettes
+	cassetteDirEnv = "UCLOUD_CASSETTE_DIR"
+
+	cassetteModeRecord = "record"
+	cassetteModeReplay = "replay"
+
+	defaultCassetteDir = "testdata/cassettes"
+
+	cassetteRedacted = "REDACTED"
+)
+
+// cassetteMaskedParams are the query params which depend on the credential or the test environment,
+// they will be masked before recording and matching.
+var cassetteMaskedParams = map[string]string{
+	"PublicKey": cassetteRedacted,
+	"ProjectId": cassetteRedacted,
+}
+
+// cassetteTimestampPattern is used to match the value of unix timestamp params, such as BeginTime
+var cassetteTimestampPattern = regexp.MustCompile(`^1[0-9]{9}$`)
+
+func cassetteMode() string {
+	return os.Getenv(cassetteModeEnv)
+}
+
+func isCassetteReplaying() bool {
+	return cassetteMode() == cassetteModeReplay
+}
+
+func cassetteDir() string {
+	if v := os.Getenv(cassetteDirEnv); v != "" {
+		return v
+	}
+	return defaultCassetteDir
+}
+
+// cassetteInteraction is a pair of request and response through the sdk,
+// the request is normalized so that it can be matched when replaying.
+type cassetteInteraction struct {
+	Action   string `json:"action"`
+	Request  string `json:"request"`
+	Response string `json:"response"`
+
+	used bool
+}
+
+type cassette struct {
+	Name         string                 `json:"name"`
+	Interactions []*cassetteInteraction `json:"interactions"`
+}
+
+// cassetteTransport is a http.RoundTripper to record the interactions with the UCloud api into cassette files,
+// and replay them later without network access.
+// Each acceptance test has its own cassette which is switched by testAccPreCheck.
+type cassetteTransport struct {
+	mu      sync.Mutex
+	mode    string
+	dir     string
+	next    http.RoundTripper
+	current *cassette
+}
+
+func newCassetteTransport(mode, dir string, next http.RoundTripper) *cassetteTransport {
+	return &cassetteTransport{mode: mode, dir: dir, next: next}
+}
+
+var testAccCassetteTransport *cassetteTransport
+
+// testAccUseCassette switches the cassette to the one of the test named by name
+func testAccUseCassette(name string) error {
+	if testAccCassetteTransport == nil {
+		return nil
+	}
+	return testAccCassetteTransport.use(name)
+}
+
+func (c *cassetteTransport) path(name string) string {
+	name = strings.NewReplacer("/", "_", " ", "_").Replace(name)
+	return filepath.Join(c.dir, name+".json")
+}
+
+func (c *cassetteTransport) use(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mode == cassetteModeRecord {
+		c.current = &cassette{Name: name}
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(c.path(name))
+	if err != nil {
+		return fmt.Errorf("cannot load cassette of %s, %s", name, err)
+	}
+
+	current := &cassette{}
+	if err := json.Unmarshal(data, current); err != nil {
+		return fmt.Errorf("cannot parse cassette of %s, %s", name, err)
+	}
+	c.current = current
+	return nil
+}
+
+func (c *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current == nil {
+		return nil, fmt.Errorf("cassette is not loaded, testAccPreCheck must be called before sending request")
+	}
+
+	action := req.URL.Query().Get("Action")
+	normalized := normalizeCassetteQuery(req.URL.Query())
+
+	if c.mode == cassetteModeReplay {
+		interaction := c.current.match(action, normalized)
+		if interaction == nil {
+			return nil, fmt.Errorf("cannot find interaction in cassette %s for %s?%s", c.current.Name, action, normalized)
+		}
+		return newCassetteResponse(req, interaction.Response), nil
+	}
+
+	resp, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the successful response is recorded, the status error will be raised by sdk
+	if resp.StatusCode < 400 {
+		c.current.Interactions = append(c.current.Interactions, &cassetteInteraction{
+			Action:   action,
+			Request:  normalized,
+			Response: redactCassetteBody(body),
+		})
+
+		if err := c.save(); err != nil {
+			log.Printf("[WARN] Test: cannot save cassette of %s, %s", c.current.Name, err)
+		}
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// save will write the whole cassette after each interaction,
+// so that the cassette is kept even if the test is interrupted.
+func (c *cassetteTransport) save() error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+
+	// keep the query string readable in cassette
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(c.current); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path(c.current.Name), buf.Bytes(), 0644)
+}
+
+// match will find the first unused interaction with the same request,
+// the repeated request such as describing for state refreshing will be replayed in recorded order.
+// If all of the matched interactions have been used, the last one will be returned.
+func (c *cassette) match(action, normalized string) *cassetteInteraction {
+	var last *cassetteInteraction
+	for _, interaction := range c.Interactions {
+		if interaction.Action != action || interaction.Request != normalized {
+			continue
+		}
+
+		if !interaction.used {
+			interaction.used = true
+			return interaction
+		}
+		last = interaction
+	}
+	return last
+}
+
+func newCassetteResponse(req *http.Request, body string) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// normalizeCassetteQuery will remove signature, mask credential, password and timestamp of query
+// and return it as a sorted query string
+func normalizeCassetteQuery(q url.Values) string {
+	var keys []string
+	for k := range q {
+		if k == "Signature" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var items []string
+	for _, k := range keys {
+		for _, v := range q[k] {
+			items = append(items, k+"="+url.QueryEscape(normalizeCassetteParam(k, v)))
+		}
+	}
+	return strings.Join(items, "&")
+}
+
+func normalizeCassetteParam(key, value string) string {
+	if v, ok := cassetteMaskedParams[key]; ok {
+		return v
+	}
+
+	if isCassettePasswordKey(key) {
+		return cassetteRedacted
+	}
+
+	if strings.HasSuffix(key, "Time") && cassetteTimestampPattern.MatchString(value) {
+		return "TIMESTAMP"
+	}
+	return value
+}
+
+func isCassettePasswordKey(key string) bool {
+	return strings.HasSuffix(strings.ToLower(key), "password")
+}
+
+// redactCassetteBody will redact the password fields of json response body
+func redactCassetteBody(body []byte) string {
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return string(body)
+	}
+
+	data = redactCassetteValue(data)
+	redacted, err := json.Marshal(data)
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+func redactCassetteValue(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if _, ok := item.(string); ok && isCassettePasswordKey(key) {
+				v[key] = cassetteRedacted
+				continue
+			}
+			v[key] = redactCassetteValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactCassetteValue(item)
+		}
+	}
+	return data
+}
+
+func Test_normalizeCassetteQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			"signature",
+			"Action=DescribeEIP&PublicKey=abc&Region=cn-sh2&Signature=xyz",
+			"Action=DescribeEIP&PublicKey=REDACTED&Region=cn-sh2",
+		},
+		{
+			"password",
+			"Action=CreateUHostInstance&Password=cGFzc3dvcmQ%3D&ProjectId=org-xxx",
+			"Action=CreateUHostInstance&Password=REDACTED&ProjectId=REDACTED",
+		},
+		{
+			"timestamp",
+			"Action=DescribeUDBBackup&BeginTime=1540000000&Limit=100",
+			"Action=DescribeUDBBackup&BeginTime=TIMESTAMP&Limit=100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := normalizeCassetteQuery(q); got != tt.want {
+				t.Errorf("normalizeCassetteQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_redactCassetteBody(t *testing.T) {
+	body := `{"Action":"DescribeUHostInstanceResponse","RetCode":0,"UHostSet":[{"CreateTime":1540000000,"Password":"secret"}]}`
+	want := `{"Action":"DescribeUHostInstanceResponse","RetCode":0,"UHostSet":[{"CreateTime":1540000000,"Password":"REDACTED"}]}`
+	if got := redactCassetteBody([]byte(body)); got != want {
+		t.Errorf("redactCassetteBody() = %v, want %v", got, want)
+	}
+}
@@ -1,6 +1,12 @@
 package ucloud
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/ucloud/ucloud-sdk-go/services/uaccount"
 	"github.com/ucloud/ucloud-sdk-go/services/udb"
 	"github.com/ucloud/ucloud-sdk-go/services/udisk"
@@ -10,7 +16,6 @@ import (
 	"github.com/ucloud/ucloud-sdk-go/services/vpc"
 	"github.com/ucloud/ucloud-sdk-go/ucloud"
 	"github.com/ucloud/ucloud-sdk-go/ucloud/auth"
-	"github.com/ucloud/ucloud-sdk-go/ucloud/log"
 )
 
 type Config struct {
@@ -22,6 +27,39 @@ type Config struct {
 	MaxRetries int
 
 	Insecure bool
+
+	// BaseUrl overrides the default public endpoint for all of products if it is not empty
+	BaseUrl string
+
+	// RequestTimeout is the timeout of each request in seconds
+	RequestTimeout int
+
+	// UserAgent will be appended to the user agent of sdk
+	UserAgent string
+
+	// Proxy is the url of http(s) proxy, the proxy of environment variables is used if it is empty
+	Proxy string
+
+	// CABundle is the path of PEM encoded certificates file
+	CABundle string
+
+	// SkipTLSVerify is used to disable the verification of certificate
+	SkipTLSVerify bool
+
+	// CredentialProcess is the command to retrieve the credential if the keys are not set
+	CredentialProcess string
+
+	// RateLimit is the max number of requests per second sent by all of the product connections, 0 means unlimited
+	RateLimit int
+
+	// RateBurst is the max number of requests sent at the same time when the rate limit is enabled
+	RateBurst int
+
+	// MaxThrottleRetries is the max retry attempts number of request throttled by server or resource busy
+	MaxThrottleRetries int
+
+	// Endpoints overrides the endpoint of product, the key is the name of product such as uhost
+	Endpoints map[string]string
 }
 
 type UCloudClient struct {
@@ -35,6 +73,20 @@ type UCloudClient struct {
 	uaccountconn *uaccount.UAccountClient
 	udiskconn    *udisk.UDiskClient
 	udbconn      *udb.UDBClient
+
+	// uhostgenericconn is used to invoke the actions of uhost which are not supported by the sdk yet
+	uhostgenericconn *ucloud.Client
+
+	// udiskgenericconn is used to invoke the actions of udisk which are not supported by the sdk yet
+	udiskgenericconn *ucloud.Client
+
+	// routeToken is used to route the requests of sdk to the transport of client
+	routeToken string
+}
+
+// Close will remove the route of client, the requests of client will not use its transport any more
+func (c *UCloudClient) Close() {
+	getTransportRouter().Deregister(c.routeToken)
 }
 
 // Client will returns a client with connections for all product
@@ -50,28 +102,75 @@ func (c *Config) Client() (*UCloudClient, error) {
 
 	// enable auto retry with http/connection error
 	config.MaxRetries = c.MaxRetries
-	config.LogLevel = log.ErrorLevel
+	config.LogLevel = sdkLogLevel()
+
+	if c.RequestTimeout > 0 {
+		config.Timeout = time.Duration(c.RequestTimeout) * time.Second
+	}
+
+	config.UserAgent = strings.TrimSpace(fmt.Sprintf("Terraform/%s %s", terraform.VersionString(), c.UserAgent))
 
-	// set endpoint with insecure https connection
-	if c.Insecure {
-		config.BaseUrl = GetEndpointURL(c.Region)
-	} else {
-		config.BaseUrl = GetInsecureEndpointURL(c.Region)
+	// all of the product connections share the same transport
+	httpTransport, err := newHTTPTransport(c)
+	if err != nil {
+		return nil, err
 	}
+	var transport http.RoundTripper = httpTransport
+
+	// every api call is logged, including the retries of throttled request
+	transport = &loggingTransport{next: transport}
+
+	// all of the product connections share the same rate limiter
+	transport = newRateLimitTransport(c.RateLimit, c.RateBurst, c.MaxThrottleRetries, transport)
 
 	// credential with publicKey/privateKey
 	credential := auth.NewCredential()
 	credential.PublicKey = c.PublicKey
 	credential.PrivateKey = c.PrivateKey
 
+	// retrieve credential by credential process, the request will be signed again after the credential is refreshed
+	if c.PublicKey == "" && c.CredentialProcess != "" {
+		process := newCredentialProcess(c.CredentialProcess)
+		publicKey, privateKey, err := process.Retrieve()
+		if err != nil {
+			return nil, err
+		}
+
+		credential.PublicKey = publicKey
+		credential.PrivateKey = privateKey
+		transport = &credentialProcessTransport{process: process, next: transport}
+	}
+
+	// the requests of each provider instance are routed to its own transport by the token in User-Agent
+	client.routeToken = getTransportRouter().Register(transport)
+	config.UserAgent = fmt.Sprintf("%s %s", config.UserAgent, client.routeToken)
+
+	resolver := &endpointResolver{
+		region:    c.Region,
+		insecure:  c.Insecure,
+		baseUrl:   c.BaseUrl,
+		endpoints: c.Endpoints,
+	}
+
+	// each product has its own config to resolve the endpoint separately
+	productConfig := func(service string) *ucloud.Config {
+		cfg := config
+		cfg.BaseUrl = resolver.Resolve(service)
+		return &cfg
+	}
+
 	// initialize client connections
-	client.uhostconn = uhost.NewClient(&config, &credential)
-	client.unetconn = unet.NewClient(&config, &credential)
-	client.ulbconn = ulb.NewClient(&config, &credential)
-	client.vpcconn = vpc.NewClient(&config, &credential)
-	client.uaccountconn = uaccount.NewClient(&config, &credential)
-	client.udiskconn = udisk.NewClient(&config, &credential)
-	client.udbconn = udb.NewClient(&config, &credential)
+	client.uhostconn = uhost.NewClient(productConfig("uhost"), &credential)
+	client.unetconn = unet.NewClient(productConfig("unet"), &credential)
+	client.ulbconn = ulb.NewClient(productConfig("ulb"), &credential)
+	client.vpcconn = vpc.NewClient(productConfig("vpc"), &credential)
+	client.uaccountconn = uaccount.NewClient(productConfig("uaccount"), &credential)
+	client.udiskconn = udisk.NewClient(productConfig("udisk"), &credential)
+	client.udbconn = udb.NewClient(productConfig("udb"), &credential)
+	client.uhostgenericconn = ucloud.NewClient(productConfig("uhost"), &credential)
+	client.udiskgenericconn = ucloud.NewClient(productConfig("udisk"), &credential)
+
+	initSDKLogger()
 
 	return &client, nil
 }
@@ -10,6 +10,21 @@ const DefaultMaxRetries = 3
 // DefaultInSecure is a default value to enable https
 const DefaultInSecure = false
 
+// DefaultRequestTimeout is the default timeout of each request in seconds
+const DefaultRequestTimeout = 30
+
+// DefaultRateLimit is the default max number of requests per second sent by provider
+const DefaultRateLimit = 20
+
+// DefaultRateBurst is the default max number of requests sent by provider at the same time
+const DefaultRateBurst = 40
+
+// DefaultMaxThrottleRetries is the default max retry attempts number of request throttled by server or resource busy
+const DefaultMaxThrottleRetries = 10
+
+// maxUserDataSize is the max size of user data of instance after it is encoded by base64
+const maxUserDataSize = 16 * 1024
+
 // DefaultWaitInterval is the inteval to wait for state changed after resource is created
 const DefaultWaitInterval = 10 * time.Second
 
@@ -0,0 +1,284 @@
+package ucloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ucloud/ucloud-sdk-go/ucloud/auth"
+)
+
+// DefaultProfile is the name of profile used if the profile is not set and there is no active profile
+const DefaultProfile = "default"
+
+// DefaultSharedCredentialsFile is the default path of credential file which is the same as ucloud cli
+const DefaultSharedCredentialsFile = "~/.ucloud/credential.json"
+
+// DefaultSharedConfigFile is the default path of config file which is the same as ucloud cli
+const DefaultSharedConfigFile = "~/.ucloud/config.json"
+
+// sharedConfig is the item of config file, such as ~/.ucloud/config.json
+type sharedConfig struct {
+	Profile   string `json:"profile"`
+	Active    bool   `json:"active"`
+	ProjectId string `json:"project_id"`
+	Region    string `json:"region"`
+	BaseUrl   string `json:"base_url"`
+}
+
+// sharedCredential is the item of credential file, such as ~/.ucloud/credential.json
+type sharedCredential struct {
+	Profile    string `json:"profile"`
+	PublicKey  string `json:"public_key"`
+	PrivateKey string `json:"private_key"`
+}
+
+// profileSettings is the settings of a named profile loaded from shared files
+type profileSettings struct {
+	PublicKey  string
+	PrivateKey string
+	Region     string
+	ProjectId  string
+	BaseUrl    string
+}
+
+// shouldLoadProfile will check if the shared files should be loaded,
+// they are only loaded if the keys are not set statically or the profile and shared files are set explicitly,
+// so that a malformed shared file will not break the provider which never asked for profiles.
+func shouldLoadProfile(publicKey, privateKey, credentialsFile, configFile, profile string) bool {
+	if publicKey == "" || privateKey == "" {
+		return true
+	}
+	return profile != "" || credentialsFile != DefaultSharedCredentialsFile || configFile != DefaultSharedConfigFile
+}
+
+// loadProfile will load the settings of profile from shared credentials file and config file.
+// If profile is empty, the active profile of config file will be used, otherwise the default profile.
+// The missing files will be ignored if they are not set explicitly.
+func loadProfile(credentialsFile, configFile, profile string) (*profileSettings, error) {
+	explicit := profile != "" || credentialsFile != DefaultSharedCredentialsFile
+
+	var configs []sharedConfig
+	if err := loadSharedFile(configFile, &configs, configFile != DefaultSharedConfigFile); err != nil {
+		return nil, err
+	}
+
+	if profile == "" {
+		profile = DefaultProfile
+		for _, item := range configs {
+			if item.Active {
+				profile = item.Profile
+				break
+			}
+		}
+	}
+
+	var credentials []sharedCredential
+	if err := loadSharedFile(credentialsFile, &credentials, explicit); err != nil {
+		return nil, err
+	}
+
+	settings := &profileSettings{}
+	found := false
+	for _, item := range credentials {
+		if item.Profile == profile {
+			settings.PublicKey = item.PublicKey
+			settings.PrivateKey = item.PrivateKey
+			found = true
+			break
+		}
+	}
+
+	for _, item := range configs {
+		if item.Profile == profile {
+			settings.Region = item.Region
+			settings.ProjectId = item.ProjectId
+			settings.BaseUrl = item.BaseUrl
+			found = true
+			break
+		}
+	}
+
+	if !found && explicit {
+		return nil, fmt.Errorf("profile %q is not found in shared credentials file %s and config file %s", profile, credentialsFile, configFile)
+	}
+
+	return settings, nil
+}
+
+// loadSharedFile will read the json file into data, the missing file will be ignored if it is not required
+func loadSharedFile(filePath string, data interface{}, required bool) error {
+	if filePath == "" {
+		return nil
+	}
+
+	absPath, err := getAbsPath(filePath)
+	if err != nil {
+		return err
+	}
+
+	bs, err := ioutil.ReadFile(absPath)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			return nil
+		}
+		return fmt.Errorf("cannot read shared file %s, %s", filePath, err)
+	}
+
+	if err := json.Unmarshal(bs, data); err != nil {
+		return fmt.Errorf("cannot parse shared file %s, %s", filePath, err)
+	}
+
+	return nil
+}
+
+// credentialProcessExpiryWindow is the duration to refresh the credential before it is expired
+const credentialProcessExpiryWindow = 1 * time.Minute
+
+// credentialProcessOutput is the json output of credential process
+type credentialProcessOutput struct {
+	PublicKey  string `json:"public_key"`
+	PrivateKey string `json:"private_key"`
+	Expiration string `json:"expiration"`
+}
+
+// credentialProcess is used to retrieve the credential by running an external command,
+// the credential will be retrieved again when it is expired.
+type credentialProcess struct {
+	mu      sync.Mutex
+	command string
+
+	publicKey  string
+	privateKey string
+	expiration time.Time
+}
+
+func newCredentialProcess(command string) *credentialProcess {
+	return &credentialProcess{command: command}
+}
+
+// Retrieve will return the cached credential, or run the command if it is not retrieved or expired
+func (p *credentialProcess) Retrieve() (string, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.publicKey != "" && !p.isExpired() {
+		return p.publicKey, p.privateKey, nil
+	}
+
+	output, err := p.run()
+	if err != nil {
+		return "", "", err
+	}
+
+	if output.PublicKey == "" || output.PrivateKey == "" {
+		return "", "", fmt.Errorf("credential process %q must output both of %q and %q", p.command, "public_key", "private_key")
+	}
+
+	var expiration time.Time
+	if output.Expiration != "" {
+		expiration, err = time.Parse(time.RFC3339, output.Expiration)
+		if err != nil {
+			return "", "", fmt.Errorf("credential process %q output invalid %q, %s", p.command, "expiration", err)
+		}
+	}
+
+	p.publicKey = output.PublicKey
+	p.privateKey = output.PrivateKey
+	p.expiration = expiration
+	return p.publicKey, p.privateKey, nil
+}
+
+func (p *credentialProcess) isExpired() bool {
+	if p.expiration.IsZero() {
+		return false
+	}
+	return time.Now().Add(credentialProcessExpiryWindow).After(p.expiration)
+}
+
+func (p *credentialProcess) run() (*credentialProcessOutput, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd.exe", "/C", p.command)
+	} else {
+		cmd = exec.Command("sh", "-c", p.command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = os.Environ()
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error in run credential process %q, %s, %s", p.command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	output := &credentialProcessOutput{}
+	if err := json.Unmarshal(stdout.Bytes(), output); err != nil {
+		return nil, fmt.Errorf("cannot parse output of credential process %q, %s", p.command, err)
+	}
+
+	return output, nil
+}
+
+// credentialProcessTransport will sign the request again with the new credential
+// if the credential of credential process has been refreshed.
+type credentialProcessTransport struct {
+	process *credentialProcess
+	next    http.RoundTripper
+}
+
+func (t *credentialProcessTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	publicKey, privateKey, err := t.process.Retrieve()
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	if q.Get("PublicKey") != publicKey || !isSignedBy(req.URL.RawQuery, privateKey) {
+		r := new(http.Request)
+		*r = *req
+		u := *req.URL
+		u.RawQuery = signQuery(q, publicKey, privateKey)
+		r.URL = &u
+		req = r
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// signQuery will build the query string signed by the credential as the same as sdk
+func signQuery(q url.Values, publicKey, privateKey string) string {
+	params := make(map[string]string)
+	for k := range q {
+		if k != "Signature" && k != "PublicKey" {
+			params[k] = q.Get(k)
+		}
+	}
+
+	credential := auth.NewCredential()
+	credential.PublicKey = publicKey
+	credential.PrivateKey = privateKey
+	return credential.BuildCredentialedQuery(params)
+}
+
+// isSignedBy will check the signature of query string is signed by the private key
+func isSignedBy(rawQuery, privateKey string) bool {
+	idx := strings.LastIndex(rawQuery, "&Signature=")
+	if idx < 0 {
+		return false
+	}
+
+	credential := auth.NewCredential()
+	credential.PrivateKey = privateKey
+	return credential.CreateSign(rawQuery[:idx]) == rawQuery[idx+len("&Signature="):]
+}
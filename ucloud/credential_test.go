@@ -0,0 +1,174 @@
+package ucloud
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func Test_loadProfile(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempdir)
+
+	credentialsFile := filepath.Join(tempdir, "credential.json")
+	configFile := filepath.Join(tempdir, "config.json")
+
+	credentials := `[
+	{"profile": "default", "public_key": "pub-default", "private_key": "pri-default"},
+	{"profile": "test", "public_key": "pub-test", "private_key": "pri-test"}
+]`
+	configs := `[
+	{"profile": "default", "active": false, "project_id": "org-default", "region": "cn-bj2"},
+	{"profile": "test", "active": true, "project_id": "org-test", "region": "cn-sh2", "base_url": "http://api.internal"}
+]`
+	if err := ioutil.WriteFile(credentialsFile, []byte(credentials), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(configFile, []byte(configs), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		credentialsFile string
+		configFile      string
+		profile         string
+		want            *profileSettings
+		wantErr         bool
+	}{
+		{
+			"active profile",
+			credentialsFile,
+			configFile,
+			"",
+			&profileSettings{"pub-test", "pri-test", "cn-sh2", "org-test", "http://api.internal"},
+			false,
+		},
+		{
+			"named profile",
+			credentialsFile,
+			configFile,
+			"default",
+			&profileSettings{"pub-default", "pri-default", "cn-bj2", "org-default", ""},
+			false,
+		},
+		{
+			"profile not found",
+			credentialsFile,
+			configFile,
+			"notfound",
+			nil,
+			true,
+		},
+		{
+			"credentials file not found",
+			filepath.Join(tempdir, "notfound.json"),
+			configFile,
+			"default",
+			nil,
+			true,
+		},
+		{
+			"default files not found",
+			DefaultSharedCredentialsFile,
+			DefaultSharedConfigFile,
+			"",
+			&profileSettings{},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.credentialsFile == DefaultSharedCredentialsFile {
+				if path, _ := getAbsPath(DefaultSharedCredentialsFile); fileExists(path) {
+					t.Skip("the default shared credentials file exists")
+				}
+			}
+
+			got, err := loadProfile(tt.credentialsFile, tt.configFile, tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("loadProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadProfile() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_shouldLoadProfile(t *testing.T) {
+	tests := []struct {
+		name            string
+		publicKey       string
+		privateKey      string
+		credentialsFile string
+		configFile      string
+		profile         string
+		want            bool
+	}{
+		{"keys missing", "", "", DefaultSharedCredentialsFile, DefaultSharedConfigFile, "", true},
+		{"private key missing", "pub", "", DefaultSharedCredentialsFile, DefaultSharedConfigFile, "", true},
+		{"static keys", "pub", "pri", DefaultSharedCredentialsFile, DefaultSharedConfigFile, "", false},
+		{"static keys with profile", "pub", "pri", DefaultSharedCredentialsFile, DefaultSharedConfigFile, "test", true},
+		{"static keys with credentials file", "pub", "pri", "/tmp/credential.json", DefaultSharedConfigFile, "", true},
+		{"static keys with config file", "pub", "pri", DefaultSharedCredentialsFile, "/tmp/config.json", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldLoadProfile(tt.publicKey, tt.privateKey, tt.credentialsFile, tt.configFile, tt.profile); got != tt.want {
+				t.Errorf("shouldLoadProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func fileExists(filePath string) bool {
+	_, err := os.Stat(filePath)
+	return err == nil
+}
+
+func Test_credentialProcess_refresh(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the credential process of test is a shell script")
+	}
+
+	tempdir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempdir)
+
+	// the first credential is invalid and expired, the second one is valid for the fake api
+	marker := filepath.Join(tempdir, "marker")
+	expired := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	command := fmt.Sprintf(
+		`if [ -f %[1]s ]; then echo '{"public_key": "%[2]s", "private_key": "%[3]s"}'; else touch %[1]s; echo '{"public_key": "%[2]s", "private_key": "stale", "expiration": "%[4]s"}'; fi`,
+		marker, fakePublicKey, fakePrivateKey, expired,
+	)
+
+	config := Config{
+		Region:            fakeRegion,
+		ProjectId:         fakeProjectId,
+		BaseUrl:           testAccFakeUCloudAPI().URL(),
+		CredentialProcess: command,
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := client.uaccountconn.NewGetRegionRequest()
+	if _, err := client.uaccountconn.GetRegion(req); err != nil {
+		t.Fatalf("the request should be signed by the refreshed credential, got %s", err)
+	}
+}
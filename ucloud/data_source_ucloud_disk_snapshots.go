@@ -0,0 +1,195 @@
+package ucloud
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/ucloud/ucloud-sdk-go/services/udisk"
+	"github.com/ucloud/ucloud-sdk-go/ucloud"
+)
+
+func dataSourceUCloudDiskSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceUCloudDiskSnapshotsRead,
+
+		Schema: map[string]*schema.Schema{
+			"availability_zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"disk_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateImageNameRegex,
+			},
+
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"total_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"snapshots": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"comment": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"disk_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"disk_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"disk_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"create_time": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"expire_time": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUCloudDiskSnapshotsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*UCloudClient).udiskconn
+
+	req := conn.NewDescribeUDiskSnapshotRequest()
+
+	if v, ok := d.GetOk("availability_zone"); ok {
+		req.Zone = ucloud.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("disk_id"); ok {
+		req.UDiskId = ucloud.String(v.(string))
+	}
+
+	var snapshots []udisk.UDiskSnapshotSet
+	var limit int = 100
+	var offset int
+	for {
+		req.Limit = ucloud.Int(limit)
+		req.Offset = ucloud.Int(offset)
+		resp, err := conn.DescribeUDiskSnapshot(req)
+		if err != nil {
+			return fmt.Errorf("error in read disk snapshot list, %s", err)
+		}
+
+		if resp == nil || len(resp.DataSet) < 1 {
+			break
+		}
+
+		snapshots = append(snapshots, resp.DataSet...)
+
+		if len(resp.DataSet) < limit {
+			break
+		}
+
+		offset = offset + limit
+	}
+
+	var filteredSnapshots []udisk.UDiskSnapshotSet
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		r := regexp.MustCompile(nameRegex.(string))
+		for _, snapshot := range snapshots {
+			if r.MatchString(snapshot.Name) {
+				filteredSnapshots = append(filteredSnapshots, snapshot)
+			}
+		}
+	} else {
+		filteredSnapshots = snapshots[:]
+	}
+
+	d.Set("total_count", len(filteredSnapshots))
+	err := dataSourceUCloudDiskSnapshotsSave(d, filteredSnapshots)
+	if err != nil {
+		return fmt.Errorf("error in read disk snapshot list, %s", err)
+	}
+
+	return nil
+}
+
+func dataSourceUCloudDiskSnapshotsSave(d *schema.ResourceData, snapshots []udisk.UDiskSnapshotSet) error {
+	ids := []string{}
+	data := []map[string]interface{}{}
+
+	for _, item := range snapshots {
+		ids = append(ids, item.SnapshotId)
+		data = append(data, map[string]interface{}{
+			"id":          item.SnapshotId,
+			"name":        item.Name,
+			"comment":     item.Comment,
+			"disk_id":     item.UDiskId,
+			"disk_name":   item.UDiskName,
+			"disk_type":   diskSnapshotTypeToString(item.DiskType),
+			"size":        item.Size,
+			"status":      item.Status,
+			"create_time": timestampToString(item.CreateTime),
+			"expire_time": timestampToString(item.ExpiredTime),
+		})
+	}
+
+	d.SetId(hashStringArray(ids))
+	if err := d.Set("snapshots", data); err != nil {
+		return err
+	}
+
+	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
+		writeToFile(outputFile.(string), data)
+	}
+
+	return nil
+}
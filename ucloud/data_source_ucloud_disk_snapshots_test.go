@@ -0,0 +1,55 @@
+package ucloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccUCloudDiskSnapshotsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataDiskSnapshotsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ucloud_disk_snapshots.foo"),
+					resource.TestCheckResourceAttr("data.ucloud_disk_snapshots.foo", "snapshots.#", "1"),
+					resource.TestCheckResourceAttr("data.ucloud_disk_snapshots.foo", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.ucloud_disk_snapshots.foo", "snapshots.0.name", "tf-testAccDiskSnapshots-daily"),
+					resource.TestCheckResourceAttr("data.ucloud_disk_snapshots.foo", "snapshots.0.status", "Normal"),
+					resource.TestCheckResourceAttrPair("data.ucloud_disk_snapshots.foo", "snapshots.0.disk_id", "ucloud_disk.foo", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataDiskSnapshotsConfig = `
+resource "ucloud_disk" "foo" {
+	availability_zone = "cn-sh2-02"
+	name = "testAccDiskSnapshots"
+	disk_size = 10
+}
+
+resource "ucloud_disk_snapshot" "daily" {
+	disk_id = "${ucloud_disk.foo.id}"
+	name = "tf-testAccDiskSnapshots-daily"
+}
+
+resource "ucloud_disk_snapshot" "weekly" {
+	disk_id = "${ucloud_disk.foo.id}"
+	name = "tf-testAccDiskSnapshots-weekly"
+
+	depends_on = ["ucloud_disk_snapshot.daily"]
+}
+
+data "ucloud_disk_snapshots" "foo" {
+	availability_zone = "cn-sh2-02"
+	disk_id = "${ucloud_disk_snapshot.weekly.disk_id}"
+	name_regex = "daily$"
+}
+`
@@ -0,0 +1,284 @@
+package ucloud
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/ucloud/ucloud-sdk-go/services/udisk"
+	"github.com/ucloud/ucloud-sdk-go/ucloud"
+)
+
+func dataSourceUCloudDisks() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceUCloudDisksRead,
+
+		Schema: map[string]*schema.Schema{
+			"availability_zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ForceNew: true,
+			},
+
+			"disk_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringInChoices([]string{"DataDisk", "SSDDataDisk", "SystemDisk"}),
+			},
+
+			"attached": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"instance_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"tag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateImageNameRegex,
+			},
+
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"total_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"disks": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"availability_zone": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"disk_size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"disk_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"disk_charge_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"data_protection": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"tag": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"instance_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"instance_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"device_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"is_expired": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"create_time": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"expire_time": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUCloudDisksRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*UCloudClient).udiskconn
+
+	req := conn.NewDescribeUDiskRequest()
+
+	if v, ok := d.GetOk("availability_zone"); ok {
+		req.Zone = ucloud.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("disk_type"); ok {
+		req.DiskType = ucloud.String(v.(string))
+	}
+
+	// the api only supports to describe one disk by id, the others are filtered after fetched
+	var ids []string
+	if v, ok := d.GetOk("ids"); ok && len(v.([]interface{})) > 0 {
+		ids = ifaceToStringSlice(v)
+		if len(ids) == 1 {
+			req.UDiskId = ucloud.String(ids[0])
+		}
+	}
+
+	var fetched []udisk.UDiskDataSet
+	var limit int = 100
+	var offset int
+	for {
+		req.Limit = ucloud.Int(limit)
+		req.Offset = ucloud.Int(offset)
+		resp, err := conn.DescribeUDisk(req)
+		if err != nil {
+			return fmt.Errorf("error in read disk list, %s", err)
+		}
+
+		if resp == nil || len(resp.DataSet) < 1 {
+			break
+		}
+
+		fetched = append(fetched, resp.DataSet...)
+
+		if len(resp.DataSet) < limit {
+			break
+		}
+
+		offset = offset + limit
+	}
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	attached, attachedOk := d.GetOkExists("attached")
+	instanceId, instanceIdOk := d.GetOk("instance_id")
+	tag, tagOk := d.GetOk("tag")
+
+	var disks []udisk.UDiskDataSet
+	for _, item := range fetched {
+		if len(ids) > 0 && checkStringIn(item.UDiskId, ids) != nil {
+			continue
+		}
+
+		if attachedOk && (item.UHostId != "") != attached.(bool) {
+			continue
+		}
+
+		if instanceIdOk && item.UHostId != instanceId.(string) {
+			continue
+		}
+
+		if tagOk && item.Tag != tag.(string) {
+			continue
+		}
+
+		if nameRegex != nil && !nameRegex.MatchString(item.Name) {
+			continue
+		}
+
+		disks = append(disks, item)
+	}
+
+	d.Set("total_count", len(disks))
+	err := dataSourceUCloudDisksSave(d, disks)
+	if err != nil {
+		return fmt.Errorf("error in read disk list, %s", err)
+	}
+
+	return nil
+}
+
+func dataSourceUCloudDisksSave(d *schema.ResourceData, disks []udisk.UDiskDataSet) error {
+	ids := []string{}
+	data := []map[string]interface{}{}
+
+	for _, item := range disks {
+		ids = append(ids, item.UDiskId)
+		data = append(data, map[string]interface{}{
+			"id":                item.UDiskId,
+			"name":              item.Name,
+			"availability_zone": item.Zone,
+			"disk_size":         item.Size,
+			"disk_type":         item.DiskType,
+			"disk_charge_type":  item.ChargeType,
+			"data_protection":   item.UDataArkMode == "Yes",
+			"tag":               item.Tag,
+			"status":            item.Status,
+			"instance_id":       item.UHostId,
+			"instance_name":     item.UHostName,
+			"device_name":       item.DeviceName,
+			"is_expired":        item.IsExpire == "Yes",
+			"create_time":       timestampToString(item.CreateTime),
+			"expire_time":       timestampToString(item.ExpiredTime),
+		})
+	}
+
+	d.SetId(hashStringArray(ids))
+	if err := d.Set("disks", data); err != nil {
+		return err
+	}
+
+	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
+		writeToFile(outputFile.(string), data)
+	}
+
+	return nil
+}
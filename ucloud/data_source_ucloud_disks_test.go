@@ -0,0 +1,91 @@
+package ucloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccUCloudDisksDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataDisksConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ucloud_disks.all"),
+					resource.TestCheckResourceAttr("data.ucloud_disks.all", "disks.#", "2"),
+					testAccCheckIDExists("data.ucloud_disks.detached"),
+					resource.TestCheckResourceAttr("data.ucloud_disks.detached", "disks.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ucloud_disks.detached", "disks.0.id", "ucloud_disk.bar", "id"),
+					resource.TestCheckResourceAttr("data.ucloud_disks.detached", "disks.0.status", "Available"),
+					resource.TestCheckResourceAttr("data.ucloud_disks.detached", "disks.0.instance_id", ""),
+					testAccCheckIDExists("data.ucloud_disks.attached"),
+					resource.TestCheckResourceAttr("data.ucloud_disks.attached", "disks.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ucloud_disks.attached", "disks.0.id", "ucloud_disk.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.ucloud_disks.attached", "disks.0.instance_id", "ucloud_instance.foo", "id"),
+					resource.TestCheckResourceAttr("data.ucloud_disks.attached", "disks.0.status", "InUse"),
+					resource.TestCheckResourceAttr("data.ucloud_disks.attached", "disks.0.disk_size", "10"),
+					resource.TestCheckResourceAttrSet("data.ucloud_disks.attached", "disks.0.device_name"),
+					resource.TestCheckResourceAttrSet("data.ucloud_disks.attached", "disks.0.expire_time"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataDisksConfig = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_disk" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name = "testAccDisks"
+	disk_size = 10
+}
+
+resource "ucloud_disk" "bar" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name = "testAccDisks"
+	disk_size = 20
+}
+
+resource "ucloud_instance" "foo" {
+	name = "tf-testAccDisks"
+	instance_type = "n-highcpu-1"
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+}
+
+resource "ucloud_disk_attachment" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	disk_id = "${ucloud_disk.foo.id}"
+	instance_id = "${ucloud_instance.foo.id}"
+}
+
+data "ucloud_disks" "all" {
+	ids = ["${ucloud_disk_attachment.foo.disk_id}", "${ucloud_disk.bar.id}"]
+	name_regex = "^testAccDisks$"
+}
+
+data "ucloud_disks" "detached" {
+	ids = ["${ucloud_disk_attachment.foo.disk_id}", "${ucloud_disk.bar.id}"]
+	attached = false
+}
+
+data "ucloud_disks" "attached" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	instance_id = "${ucloud_disk_attachment.foo.instance_id}"
+	disk_type = "DataDisk"
+}
+`
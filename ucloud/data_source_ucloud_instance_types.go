@@ -2,8 +2,6 @@ package ucloud
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -26,6 +24,21 @@ func dataSourceUCloudInstanceTypes() *schema.Resource {
 				ValidateFunc: validateIntegerInRange(1, 128),
 			},
 
+			"host_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "n",
+				ForceNew:     true,
+				ValidateFunc: validateStringInChoices(availableHostTypes),
+			},
+
+			"gpu": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIntegerInRange(1, 4),
+			},
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -50,32 +63,32 @@ func dataSourceUCloudInstanceTypes() *schema.Resource {
 func dataSourceUCloudInstanceTypesRead(d *schema.ResourceData, meta interface{}) error {
 	cpu := d.Get("cpu").(int)
 	memory := d.Get("memory").(int)
+	hostType := d.Get("host_type").(string)
+	gpu := d.Get("gpu").(int)
 
-	var instanceTypeIds []string
-	var hostScaleType string
-	if memory/cpu == 1 {
-		hostScaleType = "highcpu"
-	}
-
-	if memory/cpu == 2 {
-		hostScaleType = "basic"
+	family := instanceHostFamilies[hostType]
+	if cpu > family.MaxCPU {
+		return fmt.Errorf("error in read instance types, cpu count of %q must between 1 ~ %v", hostType, family.MaxCPU)
 	}
 
-	if memory/cpu == 4 {
-		hostScaleType = "standard"
+	if family.MaxGPU == 0 && gpu > 0 {
+		return fmt.Errorf("error in read instance types, gpu is not supported by %q", hostType)
 	}
 
-	if memory/cpu == 8 {
-		hostScaleType = "highmem"
+	if family.MaxGPU > 0 && (gpu < 1 || family.MaxGPU < gpu) {
+		return fmt.Errorf("error in read instance types, gpu count of %q must between 1 ~ %v", hostType, family.MaxGPU)
 	}
 
-	if hostScaleType != "" {
-		normal := strings.Join([]string{"n", hostScaleType, strconv.Itoa(cpu)}, "-")
-		instanceTypeIds = append(instanceTypeIds, normal)
+	var instanceTypeIds []string
+	for hostScaleType, scale := range instanceTypeScaleMap {
+		if memory*1024 == cpu*scale {
+			t := &instanceType{CPU: cpu, Memory: memory * 1024, HostType: hostType, HostScaleType: hostScaleType, GPU: gpu}
+			instanceTypeIds = append(instanceTypeIds, t.String())
+		}
 	}
 
-	customize := strings.Join([]string{"n", "customize", strconv.Itoa(cpu), strconv.Itoa(memory)}, "-")
-	instanceTypeIds = append(instanceTypeIds, customize)
+	customized := &instanceType{CPU: cpu, Memory: memory * 1024, HostType: hostType, HostScaleType: "customized", GPU: gpu}
+	instanceTypeIds = append(instanceTypeIds, customized.String())
 
 	err := dataSourceUCloudInstanceTypesSave(d, instanceTypeIds)
 	if err != nil {
@@ -18,7 +18,15 @@ func TestAccUCloudInstanceTypesDataSource(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckIDExists("data.ucloud_instance_types.foo"),
 					resource.TestCheckResourceAttr("data.ucloud_instance_types.foo", "instance_types.0.id", "n-basic-2"),
-					resource.TestCheckResourceAttr("data.ucloud_instance_types.foo", "instance_types.1.id", "n-customize-2-4"),
+					resource.TestCheckResourceAttr("data.ucloud_instance_types.foo", "instance_types.1.id", "n-customized-2-4"),
+				),
+			},
+			{
+				Config: testAccDataInstanceTypesConfigGPU,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ucloud_instance_types.foo"),
+					resource.TestCheckResourceAttr("data.ucloud_instance_types.foo", "instance_types.0.id", "g2-standard-8-2"),
+					resource.TestCheckResourceAttr("data.ucloud_instance_types.foo", "instance_types.1.id", "g2-customized-8-32-2"),
 				),
 			},
 		},
@@ -31,3 +39,12 @@ data "ucloud_instance_types" "foo" {
 	memory = 4
 }
 `
+
+const testAccDataInstanceTypesConfigGPU = `
+data "ucloud_instance_types" "foo" {
+	cpu = 8
+	memory = 32
+	host_type = "g2"
+	gpu = 2
+}
+`
@@ -1,10 +1,12 @@
 package ucloud
 
+import "strings"
+
 type endpoint string
 
 const (
-	publicEndpoint         endpoint = "http://api.ucloud.cn"
-	publicInsecureEndpoint endpoint = "https://api.ucloud.cn"
+	publicEndpoint         endpoint = "https://api.ucloud.cn"
+	publicInsecureEndpoint endpoint = "http://api.ucloud.cn"
 )
 
 // GetURL will return endpoint as string
@@ -12,12 +14,43 @@ func (e endpoint) GetURL() string {
 	return string(e)
 }
 
-// GetEndpointURL will return endpoint url string by region
+// endpointServices is the products which endpoint can be overridden by the provider argument `endpoints`
+var endpointServices = []string{"uhost", "unet", "vpc", "ulb", "udisk", "udb", "uaccount"}
+
+// GetEndpointURL will return endpoint url string by region.
+// The public api endpoint of UCloud is global and serves all of the regions by the parameter `Region` of request,
+// so that there is no default endpoint per region, the region is ignored and kept for compatibility.
+// The deployment with regional gateways should set `base_url` or `endpoints` of provider instead.
 func GetEndpointURL(region string) string {
 	return publicEndpoint.GetURL()
 }
 
-// GetInsecureEndpointURL will return endpoint url string by region
+// GetInsecureEndpointURL will return endpoint url string by region, see GetEndpointURL.
 func GetInsecureEndpointURL(region string) string {
 	return publicInsecureEndpoint.GetURL()
 }
+
+// endpointResolver is used to resolve the endpoint of each product,
+// the order of precedence is: endpoint of product > base url > global public endpoint.
+type endpointResolver struct {
+	region    string
+	insecure  bool
+	baseUrl   string
+	endpoints map[string]string
+}
+
+// Resolve will return endpoint url string of the product
+func (r *endpointResolver) Resolve(service string) string {
+	if v := r.endpoints[service]; v != "" {
+		return strings.TrimRight(v, "/")
+	}
+
+	if r.baseUrl != "" {
+		return strings.TrimRight(r.baseUrl, "/")
+	}
+
+	if r.insecure {
+		return GetInsecureEndpointURL(r.region)
+	}
+	return GetEndpointURL(r.region)
+}
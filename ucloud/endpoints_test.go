@@ -0,0 +1,64 @@
+package ucloud
+
+import "testing"
+
+func Test_endpointResolver_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver endpointResolver
+		service  string
+		want     string
+	}{
+		{
+			"default",
+			endpointResolver{region: "cn-bj2"},
+			"uhost",
+			"https://api.ucloud.cn",
+		},
+		{
+			"insecure",
+			endpointResolver{region: "cn-bj2", insecure: true},
+			"uhost",
+			"http://api.ucloud.cn",
+		},
+		{
+			"unknown region",
+			endpointResolver{region: "private-region"},
+			"uhost",
+			"https://api.ucloud.cn",
+		},
+		{
+			"base url",
+			endpointResolver{region: "cn-bj2", baseUrl: "http://gateway.internal/"},
+			"uhost",
+			"http://gateway.internal",
+		},
+		{
+			"product endpoint",
+			endpointResolver{
+				region:    "cn-bj2",
+				baseUrl:   "http://gateway.internal",
+				endpoints: map[string]string{"udb": "https://udb.gateway.internal"},
+			},
+			"udb",
+			"https://udb.gateway.internal",
+		},
+		{
+			"product endpoint of other product",
+			endpointResolver{
+				region:    "cn-bj2",
+				baseUrl:   "http://gateway.internal",
+				endpoints: map[string]string{"udb": "https://udb.gateway.internal"},
+			},
+			"uhost",
+			"http://gateway.internal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resolver.Resolve(tt.service); got != tt.want {
+				t.Errorf("endpointResolver.Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,13 +2,58 @@ package ucloud
 
 import (
 	"fmt"
-	"strings"
+
+	uerr "github.com/ucloud/ucloud-sdk-go/ucloud/error"
 )
 
+// the classes of error which are mapped from the RetCode of UCloud api
 const (
-	NotFound = "Notfound"
+	NotFound            = "Notfound"
+	Throttled           = "Throttled"
+	QuotaExceeded       = "QuotaExceeded"
+	InsufficientBalance = "InsufficientBalance"
+	InvalidParameter    = "InvalidParameter"
+	PermissionDenied    = "PermissionDenied"
+	ResourceInUse       = "ResourceInUse"
 )
 
+// errorClassRetCodes is the RetCode of each class of error
+var errorClassRetCodes = map[string][]int{
+	NotFound:            {4086, 4103, 7011, 8039, 54002, 58103},
+	Throttled:           {150, 152},
+	QuotaExceeded:       {8030, 8031},
+	InsufficientBalance: {8050, 8051},
+	InvalidParameter:    {160, 161, paramsNotAvailableRetCode},
+	PermissionDenied:    {171, 172, 174},
+	ResourceInUse:       {5009, 7013, 17060},
+}
+
+// errorClassHints is the message to tell user what happened and what to do for each class of error
+var errorClassHints = map[string]string{
+	NotFound:            "the resource is not found",
+	Throttled:           "the request is throttled by the frequency limit of api, please try again later or decrease the rate_limit of provider",
+	QuotaExceeded:       "the quota of resource is exceeded, please release the unused resources or apply for more quota",
+	InsufficientBalance: "the balance of account is insufficient, please recharge and try again",
+	InvalidParameter:    "the parameter is invalid, please check the arguments of configuration",
+	PermissionDenied:    "the permission is denied, please check the credential and its permission on the project",
+	ResourceInUse:       "the resource is in use or busy, please release its dependencies or try again later",
+}
+
+// paramsNotAvailableRetCode is the RetCode of invalid parameter, it is always classified as InvalidParameter.
+// DescribeUDBInstance also returns it if the DBId is not exist, so that describeDBInstanceById
+// converts it into not found error itself, it is never treated as not found in any other place.
+const paramsNotAvailableRetCode = 230
+
+var retCodeClasses = func() map[int]string {
+	classes := make(map[int]string)
+	for class, codes := range errorClassRetCodes {
+		for _, code := range codes {
+			classes[code] = class
+		}
+	}
+	return classes
+}()
+
 type ProviderError struct {
 	errorCode string
 	message   string
@@ -37,11 +82,76 @@ func getNotFoundMessage(product, id string) string {
 	return fmt.Sprintf("the specified %s %s is not found", product, id)
 }
 
-func isNotFoundError(err error) bool {
-	if e, ok := err.(*ProviderError); ok &&
-		(e.ErrorCode() == NotFound || strings.Contains(strings.ToLower(e.Message()), NotFound)) {
-		return true
+// actionError is the error of api action on resource,
+// the error of api is classified so that the message could tell user what to do.
+type actionError struct {
+	action       string
+	resourceType string
+	resourceId   string
+	class        string
+	err          error
+}
+
+// newActionError will return the error of api action on the resource, such as DeleteUDisk on disk bs-xxx
+func newActionError(action, resourceType, resourceId string, err error) error {
+	return &actionError{
+		action:       action,
+		resourceType: resourceType,
+		resourceId:   resourceId,
+		class:        getErrorClass(err),
+		err:          err,
+	}
+}
+
+func (e *actionError) Error() string {
+	if hint, ok := errorClassHints[e.class]; ok {
+		return fmt.Sprintf("do %s failed for %s %s, %s, %s", e.action, e.resourceType, e.resourceId, hint, e.err)
 	}
+	return fmt.Sprintf("do %s failed for %s %s, %s", e.action, e.resourceType, e.resourceId, e.err)
+}
+
+// getRetCodeClass will return the class of error by RetCode, an empty string is returned if it is unknown
+func getRetCodeClass(code int) string {
+	return retCodeClasses[code]
+}
+
+// getErrorClass will return the class of error, an empty string is returned if it cannot be classified
+func getErrorClass(err error) string {
+	switch e := err.(type) {
+	case *ProviderError:
+		return e.ErrorCode()
+	case *actionError:
+		return e.class
+	case uerr.Error:
+		return getRetCodeClass(e.Code())
+	}
+	return ""
+}
+
+func isNotFoundError(err error) bool {
+	return getErrorClass(err) == NotFound
+}
+
+func isThrottledError(err error) bool {
+	return getErrorClass(err) == Throttled
+}
+
+func isQuotaExceededError(err error) bool {
+	return getErrorClass(err) == QuotaExceeded
+}
+
+func isInsufficientBalanceError(err error) bool {
+	return getErrorClass(err) == InsufficientBalance
+}
+
+func isInvalidParameterError(err error) bool {
+	return getErrorClass(err) == InvalidParameter
+}
+
+func isPermissionDeniedError(err error) bool {
+	return getErrorClass(err) == PermissionDenied
+}
 
-	return false
+func isResourceInUseError(err error) bool {
+	return getErrorClass(err) == ResourceInUse
 }
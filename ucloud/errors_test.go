@@ -0,0 +1,49 @@
+package ucloud
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	uerr "github.com/ucloud/ucloud-sdk-go/ucloud/error"
+)
+
+func Test_getErrorClass(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"provider not found", newNotFoundError(getNotFoundMessage("disk", "bs-xxx")), NotFound},
+		{"retcode not found", uerr.NewServerCodeError(54002, "firewall not exist"), NotFound},
+		{"throttled", uerr.NewServerCodeError(152, "api frequency limit exceeded"), Throttled},
+		{"quota exceeded", uerr.NewServerCodeError(8030, "quota not enough"), QuotaExceeded},
+		{"insufficient balance", uerr.NewServerCodeError(8050, "balance not enough"), InsufficientBalance},
+		{"invalid parameter", uerr.NewServerCodeError(230, "Params [Zone] not available"), InvalidParameter},
+		{"permission denied", uerr.NewServerCodeError(172, "Access key not found"), PermissionDenied},
+		{"resource in use", uerr.NewServerCodeError(17060, "disk is busy"), ResourceInUse},
+		{"action error", newActionError("DetachUDisk", "disk attachment", "bs-xxx", uerr.NewServerCodeError(17060, "disk is busy")), ResourceInUse},
+		{"unknown retcode", uerr.NewServerCodeError(99999, "unknown"), ""},
+		{"other error", fmt.Errorf("unknown"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getErrorClass(tt.err); got != tt.want {
+				t.Errorf("getErrorClass() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newActionError(t *testing.T) {
+	err := newActionError("DeleteUDisk", "disk", "bs-xxx", uerr.NewServerCodeError(8030, "quota not enough"))
+	if !isQuotaExceededError(err) {
+		t.Errorf("isQuotaExceededError() = false, want true")
+	}
+
+	for _, want := range []string{"DeleteUDisk", "disk bs-xxx", errorClassHints[QuotaExceeded], "quota not enough"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("message %q should contain %q", err.Error(), want)
+		}
+	}
+}
@@ -0,0 +1,469 @@
+package ucloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ucloud/ucloud-sdk-go/ucloud/auth"
+)
+
+// fakeUCloudAPIEnv is the environment variable to run the acceptance tests against
+// an in-process fake UCloud api instead of the public endpoint
+const fakeUCloudAPIEnv = "UCLOUD_FAKE_API"
+
+// the credential and project accepted by the fake UCloud api
+const (
+	fakePublicKey  = "fake-public-key"
+	fakePrivateKey = "fake-private-key"
+	fakeProjectId  = "org-fake"
+	fakeRegion     = "cn-sh2"
+)
+
+// fakeTransitionPolls is the number of describe calls that a fake resource
+// will stay in a transient status before it reaches the target status
+const fakeTransitionPolls = 1
+
+func isFakeUCloudAPIEnabled() bool {
+	return os.Getenv(fakeUCloudAPIEnv) != ""
+}
+
+var (
+	fakeAPIOnce sync.Once
+	fakeAPI     *fakeUCloudAPI
+)
+
+// testAccFakeUCloudAPI will start the fake UCloud api at the first call,
+// all of the acceptance tests in the same process share the same one.
+func testAccFakeUCloudAPI() *fakeUCloudAPI {
+	fakeAPIOnce.Do(func() {
+		fakeAPI = newFakeUCloudAPI()
+		fakeAPI.server = httptest.NewServer(fakeAPI)
+		log.Printf("[INFO] Test: Using fake UCloud api at %s", fakeAPI.server.URL)
+	})
+	return fakeAPI
+}
+
+type fakeActionFunc func(q url.Values) (interface{}, error)
+
+// fakeUCloudAPI is a stateful fake of the UCloud open api,
+// it only implements the actions and the behaviors used by this provider.
+type fakeUCloudAPI struct {
+	mu      sync.Mutex
+	server  *httptest.Server
+	seq     int
+	reqSeq  int
+	keys    map[string]string
+	actions map[string]fakeActionFunc
+
+	regions []fakeRegionZone
+	images  []*fakeImage
+
+	instances map[string]*fakeInstance
+	keyPairs  map[string]*fakeKeyPair
+	eips      map[string]*fakeEIP
+	firewalls map[string]*fakeFirewall
+	bindings  map[string]string
+
+	vpcs      map[string]*fakeVPC
+	subnets   map[string]*fakeSubnet
+	intercoms map[string][]string
+
+	lbs map[string]*fakeLB
+
+	disks     map[string]*fakeDisk
+	snapshots map[string]*fakeSnapshot
+
+	dbs         map[string]*fakeDB
+	paramGroups map[int]*fakeParamGroup
+	backups     map[int]*fakeBackup
+}
+
+func newFakeUCloudAPI() *fakeUCloudAPI {
+	s := &fakeUCloudAPI{
+		keys:        map[string]string{fakePublicKey: fakePrivateKey},
+		actions:     map[string]fakeActionFunc{},
+		instances:   map[string]*fakeInstance{},
+		keyPairs:    map[string]*fakeKeyPair{},
+		eips:        map[string]*fakeEIP{},
+		firewalls:   map[string]*fakeFirewall{},
+		bindings:    map[string]string{},
+		vpcs:        map[string]*fakeVPC{},
+		subnets:     map[string]*fakeSubnet{},
+		intercoms:   map[string][]string{},
+		lbs:         map[string]*fakeLB{},
+		disks:       map[string]*fakeDisk{},
+		snapshots:   map[string]*fakeSnapshot{},
+		dbs:         map[string]*fakeDB{},
+		paramGroups: map[int]*fakeParamGroup{},
+		backups:     map[int]*fakeBackup{},
+	}
+
+	s.registerUAccount()
+	s.registerUHost()
+	s.registerUNet()
+	s.registerVPC()
+	s.registerULB()
+	s.registerUDisk()
+	s.registerUDB()
+	return s
+}
+
+// URL returns the base url of the fake UCloud api
+func (s *fakeUCloudAPI) URL() string {
+	return s.server.URL
+}
+
+func (s *fakeUCloudAPI) handle(action string, fn fakeActionFunc) {
+	s.actions[action] = fn
+}
+
+func (s *fakeUCloudAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := r.URL.Query()
+	action := q.Get("Action")
+
+	var body map[string]interface{}
+	resp, err := s.serve(action, r.URL.RawQuery, q)
+	if err != nil {
+		apiErr, ok := err.(*fakeAPIError)
+		if !ok {
+			apiErr = &fakeAPIError{code: 150, message: err.Error()}
+		}
+		body = map[string]interface{}{"RetCode": apiErr.code, "Message": apiErr.message}
+	} else {
+		body = map[string]interface{}{}
+		if resp != nil {
+			bs, err := json.Marshal(resp)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if err := json.Unmarshal(bs, &body); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		body["RetCode"] = 0
+		delete(body, "Message")
+	}
+	body["Action"] = action + "Response"
+
+	s.reqSeq++
+	body["RequestUUID"] = fmt.Sprintf("00000000-fake-4000-8000-%012d", s.reqSeq)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func (s *fakeUCloudAPI) serve(action, rawQuery string, q url.Values) (interface{}, error) {
+	if err := s.verifySignature(rawQuery, q); err != nil {
+		return nil, err
+	}
+
+	fn, ok := s.actions[action]
+	if !ok {
+		return nil, fakeErr(160, "Action [%s] not exist", action)
+	}
+
+	if q.Get("Region") != "" && !s.isRegionAvailable(q.Get("Region")) {
+		return nil, fakeErr(230, "Params [Region] not available")
+	}
+
+	if q.Get("Zone") != "" && !s.isZoneAvailable(q.Get("Zone")) {
+		return nil, fakeErr(230, "Params [Zone] not available")
+	}
+
+	return fn(q)
+}
+
+// verifySignature checks the signature as the same as the public api,
+// the signature is the sha1 of the sorted query string and the private key.
+func (s *fakeUCloudAPI) verifySignature(rawQuery string, q url.Values) error {
+	privateKey, ok := s.keys[q.Get("PublicKey")]
+	if !ok {
+		return fakeErr(172, "Access key not found")
+	}
+
+	idx := strings.LastIndex(rawQuery, "&Signature=")
+	if idx < 0 {
+		return fakeErr(171, "Signature VerifyAC Error")
+	}
+
+	credential := auth.Credential{PublicKey: q.Get("PublicKey"), PrivateKey: privateKey}
+	if credential.CreateSign(rawQuery[:idx]) != q.Get("Signature") {
+		return fakeErr(171, "Signature VerifyAC Error")
+	}
+	return nil
+}
+
+// newId will generate an unique resource id with the prefix, such as uhost-fake0001
+func (s *fakeUCloudAPI) newId(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-fake%04d", prefix, s.seq)
+}
+
+func (s *fakeUCloudAPI) newIntId() int {
+	s.seq++
+	return 1000 + s.seq
+}
+
+func (s *fakeUCloudAPI) now() int {
+	return int(time.Now().Unix())
+}
+
+type fakeAPIError struct {
+	code    int
+	message string
+}
+
+func (e *fakeAPIError) Error() string {
+	return fmt.Sprintf("[%d] %s", e.code, e.message)
+}
+
+func fakeErr(code int, format string, args ...interface{}) error {
+	return &fakeAPIError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+func fakeMissingParam(name string) error {
+	return fakeErr(160, "Missing params [%s]", name)
+}
+
+// fakeStatus will move a fake resource into its target status after it has been
+// described a few times, it is used to simulate the async status changes.
+type fakeStatus struct {
+	current string
+	target  string
+	polls   int
+}
+
+func newFakeStatus(transient, target string) fakeStatus {
+	var st fakeStatus
+	st.to(transient, target)
+	return st
+}
+
+func (st *fakeStatus) set(status string) {
+	st.current, st.target, st.polls = status, "", 0
+}
+
+func (st *fakeStatus) to(transient, target string) {
+	st.current, st.target, st.polls = transient, target, fakeTransitionPolls
+}
+
+// poll returns the current status, and moves to the target status if it is done
+func (st *fakeStatus) poll() string {
+	current := st.current
+	if st.target != "" {
+		if st.polls <= 0 {
+			st.set(st.target)
+		} else {
+			st.polls--
+		}
+	}
+	return current
+}
+
+// fakeDecode will decode the query of request into the sdk request struct,
+// it is the reverse of the query encoder of sdk.
+func fakeDecode(q url.Values, req interface{}) error {
+	v := reflect.ValueOf(req).Elem()
+	return fakeDecodeStruct(q, "", v)
+}
+
+func fakeDecodeStruct(q url.Values, prefix string, v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		sf := v.Type().Field(i)
+		if !f.CanSet() {
+			continue
+		}
+
+		name := prefix + sf.Name
+		switch f.Kind() {
+		case reflect.Struct:
+			// composited struct is flatten into query
+			if err := fakeDecodeStruct(q, prefix, f); err != nil {
+				return err
+			}
+		case reflect.Slice:
+			elemType := f.Type().Elem()
+			for j := 0; ; j++ {
+				key := fmt.Sprintf("%s.%d", name, j)
+				item := reflect.New(elemType).Elem()
+				if elemType.Kind() == reflect.Struct {
+					if !fakeHasPrefix(q, key+".") {
+						break
+					}
+					if err := fakeDecodeStruct(q, key+".", item); err != nil {
+						return err
+					}
+				} else {
+					if _, ok := q[key]; !ok {
+						break
+					}
+					if err := fakeDecodeValue(q.Get(key), item); err != nil {
+						return fmt.Errorf("invalid params [%s], %s", key, err)
+					}
+				}
+				f.Set(reflect.Append(f, item))
+			}
+		default:
+			if _, ok := q[name]; !ok {
+				continue
+			}
+			if err := fakeDecodeValue(q.Get(name), f); err != nil {
+				return fmt.Errorf("invalid params [%s], %s", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func fakeDecodeValue(s string, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		ptr := reflect.New(v.Type().Elem())
+		if err := fakeDecodeValue(s, ptr.Elem()); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported kind %s", v.Kind())
+	}
+	return nil
+}
+
+func fakeHasPrefix(q url.Values, prefix string) bool {
+	for k := range q {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeDecodeRequest decodes the query and wraps the decoding error as api error
+func fakeDecodeRequest(q url.Values, req interface{}) error {
+	if err := fakeDecode(q, req); err != nil {
+		return fakeErr(230, "%s", err)
+	}
+	return nil
+}
+
+// fakePage returns the bound of the page with limit and offset
+func fakePage(total int, limit, offset *int, defaultLimit int) (int, int) {
+	start, size := 0, defaultLimit
+	if offset != nil {
+		start = *offset
+	}
+	if limit != nil && *limit > 0 {
+		size = *limit
+	}
+	if start > total {
+		start = total
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+func fakeStringPageArgs(limit, offset *string) (*int, *int) {
+	var l, o *int
+	if limit != nil {
+		if v, err := strconv.Atoi(*limit); err == nil {
+			l = &v
+		}
+	}
+	if offset != nil {
+		if v, err := strconv.Atoi(*offset); err == nil {
+			o = &v
+		}
+	}
+	return l, o
+}
+
+func fakeSortedKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func fakeStringValue(p *string, defaultValue string) string {
+	if p == nil || *p == "" {
+		return defaultValue
+	}
+	return *p
+}
+
+func fakeIntValue(p *int, defaultValue int) int {
+	if p == nil {
+		return defaultValue
+	}
+	return *p
+}
+
+func fakeInStrings(s string, list []string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func fakeQueryValue(q url.Values, name, defaultValue string) string {
+	if v := q.Get(name); v != "" {
+		return v
+	}
+	return defaultValue
+}
@@ -0,0 +1,80 @@
+package ucloud
+
+import (
+	"net/url"
+
+	"github.com/ucloud/ucloud-sdk-go/services/uaccount"
+)
+
+type fakeRegionZone struct {
+	region string
+	zone   string
+}
+
+func (s *fakeUCloudAPI) registerUAccount() {
+	s.regions = []fakeRegionZone{
+		{"cn-sh2", "cn-sh2-01"},
+		{"cn-sh2", "cn-sh2-02"},
+		{"cn-sh2", "cn-sh2-03"},
+		{"cn-bj2", "cn-bj2-02"},
+		{"cn-bj2", "cn-bj2-03"},
+		{"cn-bj2", "cn-bj2-04"},
+		{"cn-bj2", "cn-bj2-05"},
+		{"hk", "hk-01"},
+	}
+
+	s.handle("GetRegion", s.getRegion)
+	s.handle("GetProjectList", s.getProjectList)
+}
+
+func (s *fakeUCloudAPI) isRegionAvailable(region string) bool {
+	for _, item := range s.regions {
+		if item.region == region {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fakeUCloudAPI) isZoneAvailable(zone string) bool {
+	for _, item := range s.regions {
+		if item.zone == zone {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fakeUCloudAPI) getRegion(q url.Values) (interface{}, error) {
+	resp := uaccount.GetRegionResponse{}
+	for i, item := range s.regions {
+		resp.Regions = append(resp.Regions, uaccount.RegionInfo{
+			RegionId:   1000 + i,
+			RegionName: item.region,
+			IsDefault:  item.region == fakeRegion,
+			BitMaps:    "11111111111111111111111111111111",
+			Region:     item.region,
+			Zone:       item.zone,
+		})
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) getProjectList(q url.Values) (interface{}, error) {
+	resp := uaccount.GetProjectListResponse{}
+	resp.ProjectSet = []uaccount.ProjectListInfo{
+		{
+			ProjectId:   fakeProjectId,
+			ProjectName: "Default",
+			CreateTime:  1500000000,
+			IsDefault:   true,
+		},
+		{
+			ProjectId:   fakeProjectId + "-2",
+			ProjectName: "Test",
+			CreateTime:  1500000000,
+		},
+	}
+	resp.ProjectCount = len(resp.ProjectSet)
+	return resp, nil
+}
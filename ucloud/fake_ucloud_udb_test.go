@@ -0,0 +1,603 @@
+package ucloud
+
+import (
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ucloud/ucloud-sdk-go/services/udb"
+)
+
+type fakeDB struct {
+	udb.UDBInstanceSet
+	status   fakeStatus
+	password string
+}
+
+type fakeParamGroup struct {
+	udb.UDBParamGroupSet
+}
+
+type fakeBackup struct {
+	udb.UDBBackupSet
+}
+
+func (s *fakeUCloudAPI) registerUDB() {
+	members := func(pairs ...string) []udb.UDBParamMemberSet {
+		var set []udb.UDBParamMemberSet
+		for i := 0; i+1 < len(pairs); i += 2 {
+			set = append(set, udb.UDBParamMemberSet{Key: pairs[i], Value: pairs[i+1], Modifiable: true})
+		}
+		return set
+	}
+	mysql := members("max_connections", "2000", "slow_query_log", "ON", "long_query_time", "2")
+	postgresql := members("max_connections", "2000", "log_min_duration_statement", "-1")
+
+	for id, dbType := range map[int]string{
+		18: "mysql-5.7",
+		19: "mysql-5.6",
+		20: "mysql-5.5",
+		21: "percona-5.7",
+		22: "postgresql-9.6",
+		23: "postgresql-9.4",
+	} {
+		pg := &fakeParamGroup{}
+		pg.GroupId = id
+		pg.GroupName = dbType + "默认配置"
+		pg.DBTypeId = dbType
+		pg.Description = dbType + "默认配置"
+		pg.ParamMember = mysql
+		if strings.HasPrefix(dbType, "postgresql") {
+			pg.ParamMember = postgresql
+		}
+		s.paramGroups[id] = pg
+	}
+
+	s.handle("CreateUDBInstance", s.createUDBInstance)
+	s.handle("DescribeUDBInstance", s.describeUDBInstance)
+	s.handle("ModifyUDBInstanceName", s.modifyUDBInstanceName)
+	s.handle("ModifyUDBInstancePassword", s.modifyUDBInstancePassword)
+	s.handle("ResizeUDBInstance", s.resizeUDBInstance)
+	s.handle("StartUDBInstance", s.startUDBInstance)
+	s.handle("StopUDBInstance", s.stopUDBInstance)
+	s.handle("RestartUDBInstance", s.restartUDBInstance)
+	s.handle("DeleteUDBInstance", s.deleteUDBInstance)
+	s.handle("UpdateUDBInstanceBackupStrategy", s.updateUDBInstanceBackupStrategy)
+	s.handle("EditUDBBackupBlacklist", s.editUDBBackupBlacklist)
+	s.handle("CreateUDBSlave", s.createUDBSlave)
+	s.handle("DescribeUDBParamGroup", s.describeUDBParamGroup)
+	s.handle("CreateUDBParamGroup", s.createUDBParamGroup)
+	s.handle("UploadUDBParamGroup", s.uploadUDBParamGroup)
+	s.handle("DeleteUDBParamGroup", s.deleteUDBParamGroup)
+	s.handle("DescribeUDBBackup", s.describeUDBBackup)
+}
+
+func (s *fakeUCloudAPI) getDB(q url.Values) (*fakeDB, error) {
+	id := q.Get("DBId")
+	if id == "" {
+		return nil, fakeMissingParam("DBId")
+	}
+
+	db, ok := s.dbs[id]
+	if !ok {
+		return nil, fakeErr(230, "UDB instance [%s] not exist", id)
+	}
+	return db, nil
+}
+
+func (s *fakeUCloudAPI) getParamGroup(q url.Values, name string) (*fakeParamGroup, error) {
+	id, err := strconv.Atoi(q.Get(name))
+	if err != nil {
+		return nil, fakeMissingParam(name)
+	}
+
+	pg, ok := s.paramGroups[id]
+	if !ok {
+		return nil, fakeErr(7011, "param group [%d] not exist", id)
+	}
+	return pg, nil
+}
+
+// checkIdle returns the busy error if the db instance is in a transient state
+func (db *fakeDB) checkIdle() error {
+	if db.status.target != "" {
+		return fakeErr(5009, "UDB instance [%s] is %s, please try again later", db.DBId, db.status.current)
+	}
+	return nil
+}
+
+func (s *fakeUCloudAPI) createUDBInstance(q url.Values) (interface{}, error) {
+	req := udb.CreateUDBInstanceRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	for name, missing := range map[string]bool{
+		"Zone":          req.Zone == nil,
+		"Name":          req.Name == nil,
+		"AdminPassword": req.AdminPassword == nil,
+		"DBTypeId":      req.DBTypeId == nil,
+		"Port":          req.Port == nil,
+		"DiskSpace":     req.DiskSpace == nil,
+		"MemoryLimit":   req.MemoryLimit == nil,
+	} {
+		if missing {
+			return nil, fakeMissingParam(name)
+		}
+	}
+
+	pg, err := s.getParamGroup(q, "ParamGroupId")
+	if err != nil {
+		return nil, err
+	}
+
+	if pg.DBTypeId != *req.DBTypeId {
+		return nil, fakeErr(230, "Params [ParamGroupId] not match with DBTypeId [%s]", *req.DBTypeId)
+	}
+
+	db := &fakeDB{}
+	db.DBId = s.newId("udb")
+	db.Name = *req.Name
+	db.DBTypeId = *req.DBTypeId
+	db.ParamGroupId = pg.GroupId
+	db.AdminUser = fakeStringValue(req.AdminUser, "root")
+	db.Port = *req.Port
+	db.Zone = *req.Zone
+	db.BackupZone = fakeStringValue(req.BackupZone, "")
+	db.DiskSpace = *req.DiskSpace
+	db.MemoryLimit = *req.MemoryLimit
+	db.InstanceMode = fakeStringValue(req.InstanceMode, "Normal")
+	db.ChargeType = fakeStringValue(req.ChargeType, "Month")
+	db.BackupCount = fakeIntValue(req.BackupCount, 7)
+	db.BackupBeginTime = fakeIntValue(req.BackupTime, 0)
+	db.BackupDuration = fakeIntValue(req.BackupDuration, 24)
+	db.Role = "master"
+	db.Tag = "Default"
+	db.VirtualIP = s.allocPrivateIP(s.subnets["subnet-fakedefault"])
+	db.CreateTime = s.now()
+	db.ModifyTime = db.CreateTime
+	db.ExpiredTime = db.CreateTime + 30*24*3600
+	db.status = newFakeStatus("Init", "Running")
+	db.password = *req.AdminPassword
+	s.dbs[db.DBId] = db
+
+	return udb.CreateUDBInstanceResponse{DBId: db.DBId}, nil
+}
+
+func (s *fakeUCloudAPI) describeUDBInstance(q url.Values) (interface{}, error) {
+	req := udb.DescribeUDBInstanceRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.DBId != nil {
+		db, err := s.getDB(q)
+		if err != nil {
+			return nil, err
+		}
+
+		db.State = db.status.poll()
+		return udb.DescribeUDBInstanceResponse{TotalCount: 1, DataSet: []udb.UDBInstanceSet{db.UDBInstanceSet}}, nil
+	}
+
+	if req.ClassType == nil {
+		return nil, fakeMissingParam("ClassType")
+	}
+
+	var matched []udb.UDBInstanceSet
+	for _, id := range fakeSortedKeys(s.dbs) {
+		db := s.dbs[id]
+		if req.Zone != nil && *req.Zone != db.Zone {
+			continue
+		}
+		if !strings.HasPrefix(db.DBTypeId, strings.ToLower(*req.ClassType)) {
+			continue
+		}
+
+		db.State = db.status.poll()
+		matched = append(matched, db.UDBInstanceSet)
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	resp := udb.DescribeUDBInstanceResponse{TotalCount: len(matched), DataSet: matched[start:end]}
+	if resp.DataSet == nil {
+		resp.DataSet = []udb.UDBInstanceSet{}
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) modifyUDBInstanceName(q url.Values) (interface{}, error) {
+	db, err := s.getDB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Get("Name") == "" {
+		return nil, fakeMissingParam("Name")
+	}
+
+	db.Name = q.Get("Name")
+	db.ModifyTime = s.now()
+	return udb.ModifyUDBInstanceNameResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) modifyUDBInstancePassword(q url.Values) (interface{}, error) {
+	db, err := s.getDB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Get("Password") == "" {
+		return nil, fakeMissingParam("Password")
+	}
+
+	db.password = q.Get("Password")
+	db.ModifyTime = s.now()
+	return udb.ModifyUDBInstancePasswordResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) resizeUDBInstance(q url.Values) (interface{}, error) {
+	req := udb.ResizeUDBInstanceRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	db, err := s.getDB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	if req.MemoryLimit != nil {
+		db.MemoryLimit = *req.MemoryLimit
+	}
+	if req.DiskSpace != nil {
+		db.DiskSpace = *req.DiskSpace
+	}
+	if req.InstanceMode != nil {
+		db.InstanceMode = *req.InstanceMode
+	}
+
+	db.ModifyTime = s.now()
+	db.status.to("Resizing", db.status.current)
+	return udb.ResizeUDBInstanceResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) startUDBInstance(q url.Values) (interface{}, error) {
+	db, err := s.getDB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	if db.status.current != "Shutoff" {
+		return nil, fakeErr(5010, "UDB instance [%s] is not stopped", db.DBId)
+	}
+
+	db.status.to("Starting", "Running")
+	return udb.StartUDBInstanceResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) stopUDBInstance(q url.Values) (interface{}, error) {
+	db, err := s.getDB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	if db.status.current != "Running" {
+		return nil, fakeErr(5010, "UDB instance [%s] is not running", db.DBId)
+	}
+
+	db.status.to("Stopping", "Shutoff")
+	return udb.StopUDBInstanceResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) restartUDBInstance(q url.Values) (interface{}, error) {
+	db, err := s.getDB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	if db.status.current != "Running" {
+		return nil, fakeErr(5010, "UDB instance [%s] is not running", db.DBId)
+	}
+
+	db.status.to("Restarting", "Running")
+	return udb.RestartUDBInstanceResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) deleteUDBInstance(q url.Values) (interface{}, error) {
+	db, err := s.getDB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	if db.status.current != "Shutoff" {
+		return nil, fakeErr(5010, "UDB instance [%s] must be stopped before delete", db.DBId)
+	}
+
+	delete(s.dbs, db.DBId)
+	return udb.DeleteUDBInstanceResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) updateUDBInstanceBackupStrategy(q url.Values) (interface{}, error) {
+	req := udb.UpdateUDBInstanceBackupStrategyRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	db, err := s.getDB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.BackupTime != nil {
+		db.BackupBeginTime = *req.BackupTime
+	}
+	if req.BackupDate != nil {
+		db.BackupDate = *req.BackupDate
+	}
+	return udb.UpdateUDBInstanceBackupStrategyResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) editUDBBackupBlacklist(q url.Values) (interface{}, error) {
+	db, err := s.getDB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := q["Blacklist"]; !ok {
+		return nil, fakeMissingParam("Blacklist")
+	}
+
+	db.BackupBlacklist = q.Get("Blacklist")
+	return udb.EditUDBBackupBlacklistResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) createUDBSlave(q url.Values) (interface{}, error) {
+	req := udb.CreateUDBSlaveRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.SrcId == nil {
+		return nil, fakeMissingParam("SrcId")
+	}
+	if req.Name == nil {
+		return nil, fakeMissingParam("Name")
+	}
+
+	src, ok := s.dbs[*req.SrcId]
+	if !ok {
+		return nil, fakeErr(230, "UDB instance [%s] not exist", *req.SrcId)
+	}
+
+	if src.status.current != "Running" {
+		return nil, fakeErr(5010, "UDB instance [%s] is not running", src.DBId)
+	}
+
+	paramGroupId := src.ParamGroupId
+	if req.ParamGroupId != nil {
+		pg, err := s.getParamGroup(q, "ParamGroupId")
+		if err != nil {
+			return nil, err
+		}
+		paramGroupId = pg.GroupId
+	}
+
+	db := &fakeDB{}
+	db.DBId = s.newId("udb")
+	db.Name = *req.Name
+	db.SrcDBId = src.DBId
+	db.DBTypeId = src.DBTypeId
+	db.ParamGroupId = paramGroupId
+	db.AdminUser = src.AdminUser
+	db.Port = fakeIntValue(req.Port, src.Port)
+	db.Zone = src.Zone
+	db.DiskSpace = fakeIntValue(req.DiskSpace, src.DiskSpace)
+	db.MemoryLimit = fakeIntValue(req.MemoryLimit, src.MemoryLimit)
+	db.InstanceMode = fakeStringValue(req.InstanceMode, "Normal")
+	db.ChargeType = src.ChargeType
+	db.Role = "slave"
+	db.Tag = src.Tag
+	db.VirtualIP = s.allocPrivateIP(s.subnets["subnet-fakedefault"])
+	db.CreateTime = s.now()
+	db.ModifyTime = db.CreateTime
+	db.ExpiredTime = db.CreateTime + 30*24*3600
+	db.status = newFakeStatus("Init", "Running")
+	db.password = src.password
+	s.dbs[db.DBId] = db
+
+	return udb.CreateUDBSlaveResponse{DBId: db.DBId}, nil
+}
+
+func (s *fakeUCloudAPI) describeUDBParamGroup(q url.Values) (interface{}, error) {
+	req := udb.DescribeUDBParamGroupRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.GroupId != nil {
+		pg, err := s.getParamGroup(q, "GroupId")
+		if err != nil {
+			return nil, err
+		}
+		return udb.DescribeUDBParamGroupResponse{TotalCount: 1, DataSet: []udb.UDBParamGroupSet{pg.UDBParamGroupSet}}, nil
+	}
+
+	var ids []int
+	for id := range s.paramGroups {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var matched []udb.UDBParamGroupSet
+	for _, id := range ids {
+		matched = append(matched, s.paramGroups[id].UDBParamGroupSet)
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	resp := udb.DescribeUDBParamGroupResponse{TotalCount: len(matched), DataSet: matched[start:end]}
+	if resp.DataSet == nil {
+		resp.DataSet = []udb.UDBParamGroupSet{}
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) createUDBParamGroup(q url.Values) (interface{}, error) {
+	req := udb.CreateUDBParamGroupRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.GroupName == nil {
+		return nil, fakeMissingParam("GroupName")
+	}
+	if req.DBTypeId == nil {
+		return nil, fakeMissingParam("DBTypeId")
+	}
+
+	src, err := s.getParamGroup(q, "SrcGroupId")
+	if err != nil {
+		return nil, err
+	}
+
+	if src.DBTypeId != *req.DBTypeId {
+		return nil, fakeErr(230, "Params [SrcGroupId] not match with DBTypeId [%s]", *req.DBTypeId)
+	}
+
+	pg := &fakeParamGroup{}
+	pg.GroupId = s.newIntId()
+	pg.GroupName = *req.GroupName
+	pg.DBTypeId = *req.DBTypeId
+	pg.Description = fakeStringValue(req.Description, "")
+	pg.Modifiable = true
+	pg.ParamMember = append([]udb.UDBParamMemberSet{}, src.ParamMember...)
+	s.paramGroups[pg.GroupId] = pg
+
+	return udb.CreateUDBParamGroupResponse{GroupId: pg.GroupId}, nil
+}
+
+func (s *fakeUCloudAPI) uploadUDBParamGroup(q url.Values) (interface{}, error) {
+	req := udb.UploadUDBParamGroupRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.GroupName == nil {
+		return nil, fakeMissingParam("GroupName")
+	}
+	if req.DBTypeId == nil {
+		return nil, fakeMissingParam("DBTypeId")
+	}
+	if req.Content == nil {
+		return nil, fakeMissingParam("Content")
+	}
+
+	content, err := base64.StdEncoding.DecodeString(*req.Content)
+	if err != nil {
+		return nil, fakeErr(230, "Params [Content] is not base64 encoded")
+	}
+
+	var members []udb.UDBParamMemberSet
+	for _, line := range strings.Split(string(content), "\n") {
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		members = append(members, udb.UDBParamMemberSet{
+			Key:        strings.TrimSpace(kv[0]),
+			Value:      strings.TrimSpace(kv[1]),
+			Modifiable: true,
+		})
+	}
+
+	pg := &fakeParamGroup{}
+	pg.GroupId = s.newIntId()
+	pg.GroupName = *req.GroupName
+	pg.DBTypeId = *req.DBTypeId
+	pg.Description = fakeStringValue(req.Description, "")
+	pg.Modifiable = true
+	pg.ParamMember = members
+	s.paramGroups[pg.GroupId] = pg
+
+	return udb.UploadUDBParamGroupResponse{GroupId: pg.GroupId}, nil
+}
+
+func (s *fakeUCloudAPI) deleteUDBParamGroup(q url.Values) (interface{}, error) {
+	pg, err := s.getParamGroup(q, "GroupId")
+	if err != nil {
+		return nil, err
+	}
+
+	if !pg.Modifiable {
+		return nil, fakeErr(7012, "default param group [%d] can not be deleted", pg.GroupId)
+	}
+
+	for _, db := range s.dbs {
+		if db.ParamGroupId == pg.GroupId {
+			return nil, fakeErr(7013, "param group [%d] is in use", pg.GroupId)
+		}
+	}
+
+	delete(s.paramGroups, pg.GroupId)
+	return udb.DeleteUDBParamGroupResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) describeUDBBackup(q url.Values) (interface{}, error) {
+	req := udb.DescribeUDBBackupRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for id := range s.backups {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var matched []udb.UDBBackupSet
+	for _, id := range ids {
+		backup := s.backups[id]
+		if req.BackupId != nil && *req.BackupId != id {
+			continue
+		}
+		if req.DBId != nil && *req.DBId != backup.DBId {
+			continue
+		}
+		if req.Zone != nil && *req.Zone != backup.Zone {
+			continue
+		}
+		if req.BackupType != nil && *req.BackupType != backup.BackupType {
+			continue
+		}
+		matched = append(matched, backup.UDBBackupSet)
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	resp := udb.DescribeUDBBackupResponse{TotalCount: len(matched), DataSet: matched[start:end]}
+	if resp.DataSet == nil {
+		resp.DataSet = []udb.UDBBackupSet{}
+	}
+	return resp, nil
+}
@@ -0,0 +1,573 @@
+package ucloud
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ucloud/ucloud-sdk-go/services/udisk"
+	"github.com/ucloud/ucloud-sdk-go/services/uhost"
+)
+
+type fakeDisk struct {
+	udisk.UDiskDataSet
+	status fakeStatus
+}
+
+type fakeSnapshot struct {
+	udisk.UDiskSnapshotSet
+	zone   string
+	status fakeStatus
+}
+
+func (s *fakeUCloudAPI) registerUDisk() {
+	s.handle("CreateUDisk", s.createUDisk)
+	s.handle("CloneUDisk", s.cloneUDisk)
+	s.handle("CloneUDiskSnapshot", s.cloneUDiskSnapshot)
+	s.handle("DescribeUDisk", s.describeUDisk)
+	s.handle("RenameUDisk", s.renameUDisk)
+	s.handle("ResizeUDisk", s.resizeUDisk)
+	s.handle("SetUDiskUDataArkMode", s.setUDiskUDataArkMode)
+	s.handle("RestoreUDisk", s.restoreUDisk)
+	s.handle("DeleteUDisk", s.deleteUDisk)
+	s.handle("AttachUDisk", s.attachUDisk)
+	s.handle("DetachUDisk", s.detachUDisk)
+	s.handle("CreateUDiskSnapshot", s.createUDiskSnapshot)
+	s.handle("DescribeUDiskSnapshot", s.describeUDiskSnapshot)
+	s.handle("DeleteUDiskSnapshot", s.deleteUDiskSnapshot)
+}
+
+func (s *fakeUCloudAPI) getDisk(q url.Values) (*fakeDisk, error) {
+	id := q.Get("UDiskId")
+	if id == "" {
+		return nil, fakeMissingParam("UDiskId")
+	}
+
+	disk, ok := s.disks[id]
+	if !ok {
+		return nil, fakeErr(17001, "UDisk [%s] not exist", id)
+	}
+	return disk, nil
+}
+
+// getInstanceDataDisk returns the instance and the index of its cloud data disk which is created with instance
+func (s *fakeUCloudAPI) getInstanceDataDisk(diskId string) (*fakeInstance, int) {
+	for _, instance := range s.instances {
+		for i, disk := range instance.DiskSet {
+			if disk.DiskId == diskId && disk.Type == "Data" && strings.HasPrefix(disk.DiskType, "CLOUD_") {
+				return instance, i
+			}
+		}
+	}
+	return nil, 0
+}
+
+// checkIdle returns the busy error if the disk is in a transient status
+func (disk *fakeDisk) checkIdle() error {
+	if disk.status.target != "" {
+		return fakeErr(17060, "UDisk [%s] is busy in %s, please try again later", disk.UDiskId, disk.status.current)
+	}
+	return nil
+}
+
+func (s *fakeUCloudAPI) createUDisk(q url.Values) (interface{}, error) {
+	req := udisk.CreateUDiskRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.Zone == nil {
+		return nil, fakeMissingParam("Zone")
+	}
+	if req.Size == nil {
+		return nil, fakeMissingParam("Size")
+	}
+	if req.Name == nil {
+		return nil, fakeMissingParam("Name")
+	}
+
+	diskType := fakeStringValue(req.DiskType, "DataDisk")
+	if diskType != "DataDisk" && diskType != "SSDDataDisk" {
+		return nil, fakeErr(230, "Params [DiskType] not available")
+	}
+
+	if *req.Size < 1 || *req.Size > 8000 {
+		return nil, fakeErr(230, "Params [Size] not available")
+	}
+
+	disk := s.newDisk(*req.Zone, *req.Name, *req.Size, diskType, "Initializating")
+	disk.ChargeType = fakeStringValue(req.ChargeType, "Month")
+	disk.Tag = fakeStringValue(req.Tag, "Default")
+	disk.UDataArkMode = fakeStringValue(req.UDataArkMode, "No")
+
+	return udisk.CreateUDiskResponse{UDiskId: []string{disk.UDiskId}}, nil
+}
+
+// newDisk will add a new disk which is available after it is in the transient status
+func (s *fakeUCloudAPI) newDisk(zone, name string, size int, diskType, transient string) *fakeDisk {
+	disk := &fakeDisk{}
+	disk.UDiskId = s.newId("bsm")
+	disk.Zone = zone
+	disk.Name = name
+	disk.Size = size
+	disk.DiskType = diskType
+	disk.ChargeType = "Month"
+	disk.Tag = "Default"
+	disk.UDataArkMode = "No"
+	disk.IsExpire = "No"
+	disk.Version = "v2"
+	disk.SnapshotLimit = 3
+	disk.CreateTime = s.now()
+	disk.ExpiredTime = disk.CreateTime + 30*24*3600
+	disk.status = newFakeStatus(transient, "Available")
+	s.disks[disk.UDiskId] = disk
+	return disk
+}
+
+func (s *fakeUCloudAPI) cloneUDisk(q url.Values) (interface{}, error) {
+	tagReq := cloneUDiskRequest{}
+	if err := fakeDecodeRequest(q, &tagReq); err != nil {
+		return nil, err
+	}
+	req := tagReq.CloneUDiskRequest
+
+	if req.Zone == nil {
+		return nil, fakeMissingParam("Zone")
+	}
+	if req.Name == nil {
+		return nil, fakeMissingParam("Name")
+	}
+	if req.SourceId == nil {
+		return nil, fakeMissingParam("SourceId")
+	}
+
+	source, ok := s.disks[*req.SourceId]
+	if !ok || source.Zone != *req.Zone {
+		return nil, fakeErr(17001, "UDisk [%s] not exist", *req.SourceId)
+	}
+
+	if err := source.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	disk := s.newDisk(*req.Zone, *req.Name, source.Size, source.DiskType, "Cloning")
+	disk.ChargeType = fakeStringValue(req.ChargeType, "Month")
+	disk.Tag = fakeStringValue(tagReq.Tag, "Default")
+	disk.UDataArkMode = fakeStringValue(req.UDataArkMode, "No")
+
+	return udisk.CloneUDiskResponse{UDiskId: []string{disk.UDiskId}}, nil
+}
+
+func (s *fakeUCloudAPI) cloneUDiskSnapshot(q url.Values) (interface{}, error) {
+	tagReq := cloneUDiskSnapshotRequest{}
+	if err := fakeDecodeRequest(q, &tagReq); err != nil {
+		return nil, err
+	}
+	req := tagReq.CloneUDiskSnapshotRequest
+
+	if req.Zone == nil {
+		return nil, fakeMissingParam("Zone")
+	}
+	if req.Name == nil {
+		return nil, fakeMissingParam("Name")
+	}
+	if req.SourceId == nil {
+		return nil, fakeMissingParam("SourceId")
+	}
+	if req.Size == nil {
+		return nil, fakeMissingParam("Size")
+	}
+
+	snapshot, ok := s.snapshots[*req.SourceId]
+	if !ok || snapshot.zone != *req.Zone {
+		return nil, fakeErr(17071, "Snapshot [%s] not exist", *req.SourceId)
+	}
+
+	if snapshot.status.current != "Normal" {
+		return nil, fakeErr(17060, "Snapshot [%s] is busy in %s, please try again later", snapshot.SnapshotId, snapshot.status.current)
+	}
+
+	if *req.Size < snapshot.Size {
+		return nil, fakeErr(17064, "UDisk can not be shrunk from %d to %d", snapshot.Size, *req.Size)
+	}
+
+	diskType := "DataDisk"
+	if source, ok := s.disks[snapshot.UDiskId]; ok {
+		diskType = source.DiskType
+	}
+
+	disk := s.newDisk(*req.Zone, *req.Name, *req.Size, diskType, "Cloning")
+	disk.ChargeType = fakeStringValue(req.ChargeType, "Month")
+	disk.Tag = fakeStringValue(tagReq.Tag, "Default")
+	disk.UDataArkMode = fakeStringValue(req.UDataArkMode, "No")
+
+	return udisk.CloneUDiskSnapshotResponse{UDiskId: []string{disk.UDiskId}}, nil
+}
+
+func (s *fakeUCloudAPI) describeUDisk(q url.Values) (interface{}, error) {
+	req := udisk.DescribeUDiskRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	var matched []udisk.UDiskDataSet
+	for _, id := range fakeSortedKeys(s.disks) {
+		disk := s.disks[id]
+		if req.UDiskId != nil && *req.UDiskId != id {
+			continue
+		}
+		if req.Zone != nil && *req.Zone != disk.Zone {
+			continue
+		}
+		if req.DiskType != nil && *req.DiskType != disk.DiskType {
+			continue
+		}
+
+		disk.Status = disk.status.poll()
+		matched = append(matched, disk.UDiskDataSet)
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	resp := udisk.DescribeUDiskResponse{TotalCount: len(matched), DataSet: matched[start:end]}
+	if resp.DataSet == nil {
+		resp.DataSet = []udisk.UDiskDataSet{}
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) renameUDisk(q url.Values) (interface{}, error) {
+	disk, err := s.getDisk(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Get("UDiskName") == "" {
+		return nil, fakeMissingParam("UDiskName")
+	}
+
+	disk.Name = q.Get("UDiskName")
+	return udisk.RenameUDiskResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) resizeUDisk(q url.Values) (interface{}, error) {
+	req := udisk.ResizeUDiskRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.Size == nil {
+		return nil, fakeMissingParam("Size")
+	}
+
+	// the cloud data disk created with instance is resized when the instance is stopped
+	if instance, i := s.getInstanceDataDisk(q.Get("UDiskId")); instance != nil {
+		if instance.status.current != "Stopped" {
+			return nil, fakeErr(8010, "UHost [%s] must be stopped before resize", instance.UHostId)
+		}
+		if *req.Size < instance.DiskSet[i].Size {
+			return nil, fakeErr(17064, "UDisk [%s] can not be shrunk from %d to %d", instance.DiskSet[i].DiskId, instance.DiskSet[i].Size, *req.Size)
+		}
+		instance.DiskSet[i].Size = *req.Size
+		return udisk.ResizeUDiskResponse{}, nil
+	}
+
+	disk, err := s.getDisk(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := disk.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	// the udisk must be detached before resize
+	if disk.status.current != "Available" {
+		return nil, fakeErr(17063, "UDisk [%s] must be detached before resize", disk.UDiskId)
+	}
+
+	if *req.Size < disk.Size {
+		return nil, fakeErr(17064, "UDisk [%s] can not be shrunk from %d to %d", disk.UDiskId, disk.Size, *req.Size)
+	}
+
+	disk.Size = *req.Size
+	disk.status.to("Restoring", "Available")
+	return udisk.ResizeUDiskResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) setUDiskUDataArkMode(q url.Values) (interface{}, error) {
+	req := udisk.SetUDiskUDataArkModeRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.UDataArkMode == nil {
+		return nil, fakeMissingParam("UDataArkMode")
+	}
+
+	if *req.UDataArkMode != "Yes" && *req.UDataArkMode != "No" {
+		return nil, fakeErr(230, "Params [UDataArkMode] not available")
+	}
+
+	disk, err := s.getDisk(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := disk.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	disk.UDataArkMode = *req.UDataArkMode
+	return udisk.SetUDiskUDataArkModeResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) restoreUDisk(q url.Values) (interface{}, error) {
+	disk, err := s.getDisk(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := disk.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	// the udisk must be detached before restore
+	if disk.status.current != "Available" {
+		return nil, fakeErr(17063, "UDisk [%s] must be detached before restore", disk.UDiskId)
+	}
+
+	if id := q.Get("SnapshotId"); id != "" {
+		snapshot, ok := s.snapshots[id]
+		if !ok || snapshot.UDiskId != disk.UDiskId {
+			return nil, fakeErr(17071, "Snapshot [%s] of UDisk [%s] not exist", id, disk.UDiskId)
+		}
+	} else if q.Get("SnapshotTime") == "" || disk.UDataArkMode != "Yes" {
+		return nil, fakeMissingParam("SnapshotId")
+	}
+
+	disk.status.to("Restoring", "Available")
+	return udisk.RestoreUDiskResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) deleteUDisk(q url.Values) (interface{}, error) {
+	disk, err := s.getDisk(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := disk.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	if disk.status.current != "Available" {
+		return nil, fakeErr(17065, "UDisk [%s] is in use", disk.UDiskId)
+	}
+
+	delete(s.disks, disk.UDiskId)
+	return udisk.DeleteUDiskResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) attachUDisk(q url.Values) (interface{}, error) {
+	disk, err := s.getDisk(q)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := disk.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	if disk.status.current != "Available" {
+		return nil, fakeErr(17066, "UDisk [%s] has been attached to [%s]", disk.UDiskId, disk.UHostId)
+	}
+
+	if disk.Zone != instance.Zone {
+		return nil, fakeErr(17067, "UDisk [%s] and UHost [%s] are not in the same zone", disk.UDiskId, instance.UHostId)
+	}
+
+	used := map[string]bool{}
+	for _, item := range instance.DiskSet {
+		used["/dev/"+item.Drive] = true
+	}
+	for c := 'b'; c <= 'z'; c++ {
+		if name := fmt.Sprintf("/dev/vd%c", c); !used[name] {
+			disk.DeviceName = name
+			break
+		}
+	}
+
+	disk.UHostId = instance.UHostId
+	disk.UHostName = instance.Name
+	disk.UHostIP = instance.IPSet[0].IP
+	disk.status.to("Attaching", "InUse")
+
+	diskType := "CLOUD_NORMAL"
+	if disk.DiskType == "SSDDataDisk" {
+		diskType = "CLOUD_SSD"
+	}
+	instance.DiskSet = append(instance.DiskSet, uhost.UHostDiskSet{
+		Type:     "Udisk",
+		DiskId:   disk.UDiskId,
+		Name:     disk.Name,
+		Drive:    disk.DeviceName[len("/dev/"):],
+		Size:     disk.Size,
+		IsBoot:   "False",
+		DiskType: diskType,
+	})
+
+	return udisk.AttachUDiskResponse{UHostId: instance.UHostId, UDiskId: disk.UDiskId}, nil
+}
+
+func (s *fakeUCloudAPI) detachUDisk(q url.Values) (interface{}, error) {
+	disk, err := s.getDisk(q)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := disk.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	if disk.UHostId != instance.UHostId {
+		return nil, fakeErr(17062, "UDisk [%s] is not attached to UHost [%s]", disk.UDiskId, instance.UHostId)
+	}
+
+	s.detachDisk(disk, instance)
+	disk.status.to("Detaching", "Available")
+	return udisk.DetachUDiskResponse{UHostId: instance.UHostId, UDiskId: disk.UDiskId}, nil
+}
+
+// detachDisk removes the relationship between disk and instance
+func (s *fakeUCloudAPI) detachDisk(disk *fakeDisk, instance *fakeInstance) {
+	var diskSet []uhost.UHostDiskSet
+	for _, item := range instance.DiskSet {
+		if item.DiskId != disk.UDiskId {
+			diskSet = append(diskSet, item)
+		}
+	}
+	instance.DiskSet = diskSet
+
+	disk.UHostId = ""
+	disk.UHostName = ""
+	disk.UHostIP = ""
+	disk.DeviceName = ""
+}
+
+func (s *fakeUCloudAPI) createUDiskSnapshot(q url.Values) (interface{}, error) {
+	req := udisk.CreateUDiskSnapshotRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.Zone == nil {
+		return nil, fakeMissingParam("Zone")
+	}
+	if req.Name == nil {
+		return nil, fakeMissingParam("Name")
+	}
+
+	disk, err := s.getDisk(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := disk.checkIdle(); err != nil {
+		return nil, err
+	}
+
+	if disk.Zone != *req.Zone {
+		return nil, fakeErr(17001, "UDisk [%s] not exist", disk.UDiskId)
+	}
+
+	if disk.SnapshotCount >= disk.SnapshotLimit {
+		return nil, fakeErr(17070, "UDisk [%s] snapshot count exceeds the limit %d", disk.UDiskId, disk.SnapshotLimit)
+	}
+
+	snapshot := &fakeSnapshot{}
+	snapshot.SnapshotId = s.newId("bsnap")
+	snapshot.Name = *req.Name
+	snapshot.Comment = fakeStringValue(req.Comment, "")
+	snapshot.UDiskId = disk.UDiskId
+	snapshot.UDiskName = disk.Name
+	snapshot.UHostId = disk.UHostId
+	snapshot.Size = disk.Size
+	snapshot.IsUDiskAvailable = true
+	snapshot.Version = "v2"
+	snapshot.CreateTime = s.now()
+	snapshot.ExpiredTime = snapshot.CreateTime + 30*24*3600
+	snapshot.zone = disk.Zone
+	snapshot.status = newFakeStatus("Creating", "Normal")
+	s.snapshots[snapshot.SnapshotId] = snapshot
+	disk.SnapshotCount++
+
+	return udisk.CreateUDiskSnapshotResponse{SnapshotId: []string{snapshot.SnapshotId}}, nil
+}
+
+func (s *fakeUCloudAPI) describeUDiskSnapshot(q url.Values) (interface{}, error) {
+	req := udisk.DescribeUDiskSnapshotRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	var matched []udisk.UDiskSnapshotSet
+	for _, id := range fakeSortedKeys(s.snapshots) {
+		snapshot := s.snapshots[id]
+		if req.SnapshotId != nil && *req.SnapshotId != id {
+			continue
+		}
+		if req.UDiskId != nil && *req.UDiskId != snapshot.UDiskId {
+			continue
+		}
+		if req.Zone != nil && *req.Zone != snapshot.zone {
+			continue
+		}
+
+		snapshot.Status = snapshot.status.poll()
+		_, snapshot.IsUDiskAvailable = s.disks[snapshot.UDiskId]
+		matched = append(matched, snapshot.UDiskSnapshotSet)
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	resp := udisk.DescribeUDiskSnapshotResponse{TotalCount: len(matched), DataSet: matched[start:end]}
+	if resp.DataSet == nil {
+		resp.DataSet = []udisk.UDiskSnapshotSet{}
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) deleteUDiskSnapshot(q url.Values) (interface{}, error) {
+	req := udisk.DeleteUDiskSnapshotRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.SnapshotId == nil {
+		return nil, fakeMissingParam("SnapshotId")
+	}
+
+	snapshot, ok := s.snapshots[*req.SnapshotId]
+	if !ok || (req.Zone != nil && *req.Zone != snapshot.zone) {
+		return nil, fakeErr(17071, "Snapshot [%s] not exist", *req.SnapshotId)
+	}
+
+	if snapshot.status.target != "" {
+		return nil, fakeErr(17060, "Snapshot [%s] is busy in %s, please try again later", snapshot.SnapshotId, snapshot.status.current)
+	}
+
+	if disk, ok := s.disks[snapshot.UDiskId]; ok {
+		disk.SnapshotCount--
+	}
+
+	delete(s.snapshots, snapshot.SnapshotId)
+	return udisk.DeleteUDiskSnapshotResponse{}, nil
+}
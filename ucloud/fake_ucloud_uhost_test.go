@@ -0,0 +1,820 @@
+package ucloud
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/ucloud/ucloud-sdk-go/services/uhost"
+)
+
+type fakeImage struct {
+	uhost.UHostImageSet
+	status fakeStatus
+}
+
+type fakeInstance struct {
+	uhost.UHostInstanceSet
+	status     fakeStatus
+	password   string
+	keyPairId  string
+	userData   string
+	privateMac string
+}
+
+// fakeUHostInstanceSet is the instance in response with the mac address of ip set,
+// which is returned by api but not in the sdk
+type fakeUHostInstanceSet struct {
+	uhost.UHostInstanceSet
+	IPSet []fakeUHostIPSet
+}
+
+type fakeUHostIPSet struct {
+	uhost.UHostIPSet
+	Mac string `json:",omitempty"`
+}
+
+type fakeKeyPair struct {
+	uhostKeyPair
+	publicKey string
+}
+
+func (s *fakeUCloudAPI) registerUHost() {
+	s.images = []*fakeImage{
+		{UHostImageSet: uhost.UHostImageSet{ImageId: "uimage-of3pac", ImageName: "CentOS 7.2 64位", OsType: "Linux", OsName: "CentOS 7.2 64位", ImageType: "Base", State: "Available", ImageSize: 20, CreateTime: 1500000000}},
+		{UHostImageSet: uhost.UHostImageSet{ImageId: "uimage-fakec71", ImageName: "CentOS 7.1 64位", OsType: "Linux", OsName: "CentOS 7.1 64位", ImageType: "Base", State: "Available", ImageSize: 20, CreateTime: 1500000000}},
+		{UHostImageSet: uhost.UHostImageSet{ImageId: "uimage-fakeu16", ImageName: "Ubuntu 16.04 64位", OsType: "Linux", OsName: "Ubuntu 16.04 64位", ImageType: "Base", State: "Available", ImageSize: 20, CreateTime: 1500000000}},
+		{UHostImageSet: uhost.UHostImageSet{ImageId: "uimage-fakew12", ImageName: "Windows 2012 64位", OsType: "Windows", OsName: "Windows 2012 64位", ImageType: "Base", State: "Available", ImageSize: 40, CreateTime: 1500000000}},
+	}
+
+	s.handle("DescribeImage", s.describeImage)
+	s.handle("CreateCustomImage", s.createCustomImage)
+	s.handle("CopyCustomImage", s.copyCustomImage)
+	s.handle("ImportCustomImage", s.importCustomImage)
+	s.handle("UpdateImageAttribute", s.updateImageAttribute)
+	s.handle("TerminateCustomImage", s.terminateCustomImage)
+	s.handle("CreateUHostInstance", s.createUHostInstance)
+	s.handle("DescribeUHostInstance", s.describeUHostInstance)
+	s.handle("StartUHostInstance", s.startUHostInstance)
+	s.handle("StopUHostInstance", s.stopUHostInstance)
+	s.handle("RebootUHostInstance", s.rebootUHostInstance)
+	s.handle("ResizeUHostInstance", s.resizeUHostInstance)
+	s.handle("ResetUHostInstancePassword", s.resetUHostInstancePassword)
+	s.handle("ReinstallUHostInstance", s.reinstallUHostInstance)
+	s.handle("UpgradeToArkUHostInstance", s.upgradeToArkUHostInstance)
+	s.handle("ModifyUHostInstanceName", s.modifyUHostInstanceName)
+	s.handle("ModifyUHostInstanceTag", s.modifyUHostInstanceTag)
+	s.handle("ModifyUHostInstanceRemark", s.modifyUHostInstanceRemark)
+	s.handle("TerminateUHostInstance", s.terminateUHostInstance)
+	s.handle("ImportUHostKeyPairs", s.importUHostKeyPairs)
+	s.handle("DescribeUHostKeyPairs", s.describeUHostKeyPairs)
+	s.handle("DeleteUHostKeyPairs", s.deleteUHostKeyPairs)
+}
+
+func (s *fakeUCloudAPI) getImage(imageId string) *fakeImage {
+	for _, image := range s.images {
+		if image.ImageId == imageId {
+			return image
+		}
+	}
+	return nil
+}
+
+func (s *fakeUCloudAPI) getInstance(q url.Values) (*fakeInstance, error) {
+	id := q.Get("UHostId")
+	if id == "" {
+		return nil, fakeMissingParam("UHostId")
+	}
+
+	instance, ok := s.instances[id]
+	if !ok {
+		return nil, fakeErr(8039, "UHost [%s] not exist", id)
+	}
+	return instance, nil
+}
+
+func (s *fakeUCloudAPI) describeImage(q url.Values) (interface{}, error) {
+	req := uhost.DescribeImageRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	var matched []uhost.UHostImageSet
+	for _, image := range s.images {
+		if req.ImageId != nil && *req.ImageId != image.ImageId {
+			continue
+		}
+		if req.ImageType != nil && *req.ImageType != image.ImageType {
+			continue
+		}
+		if req.OsType != nil && *req.OsType != image.OsType {
+			continue
+		}
+
+		item := image.UHostImageSet
+		if item.Zone == "" {
+			item.Zone = fakeStringValue(req.Zone, "cn-sh2-02")
+		}
+		if image.status.current != "" {
+			item.State = image.status.poll()
+		}
+		matched = append(matched, item)
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	resp := uhost.DescribeImageResponse{TotalCount: len(matched), ImageSet: matched[start:end]}
+	if resp.ImageSet == nil {
+		resp.ImageSet = []uhost.UHostImageSet{}
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) getCustomImage(imageId *string) (*fakeImage, error) {
+	if imageId == nil {
+		return nil, fakeMissingParam("ImageId")
+	}
+
+	image := s.getImage(*imageId)
+	if image == nil {
+		return nil, fakeErr(8040, "Image [%s] not exist", *imageId)
+	}
+
+	if image.ImageType != "Custom" {
+		return nil, fakeErr(8042, "Image [%s] is not a custom image", *imageId)
+	}
+	return image, nil
+}
+
+func (s *fakeUCloudAPI) createCustomImage(q url.Values) (interface{}, error) {
+	req := uhost.CreateCustomImageRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.ImageName == nil {
+		return nil, fakeMissingParam("ImageName")
+	}
+
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	image := &fakeImage{}
+	image.ImageId = s.newId("uimage")
+	image.ImageName = *req.ImageName
+	image.ImageDescription = fakeStringValue(req.ImageDescription, "")
+	image.ImageType = "Custom"
+	image.Zone = instance.Zone
+	image.OsType = instance.OsType
+	image.OsName = instance.OsName
+	image.ImageSize = 20
+	for _, disk := range instance.DiskSet {
+		if disk.Type == "Boot" {
+			image.ImageSize = disk.Size
+		}
+	}
+	image.CreateTime = s.now()
+	image.status = newFakeStatus("Making", "Available")
+
+	s.images = append(s.images, image)
+	return uhost.CreateCustomImageResponse{ImageId: image.ImageId}, nil
+}
+
+func (s *fakeUCloudAPI) copyCustomImage(q url.Values) (interface{}, error) {
+	req := uhost.CopyCustomImageRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.TargetProjectId == nil {
+		return nil, fakeMissingParam("TargetProjectId")
+	}
+
+	if req.TargetRegion != nil && !s.isRegionAvailable(*req.TargetRegion) {
+		return nil, fakeErr(230, "Params [TargetRegion] not available")
+	}
+
+	source, err := s.getCustomImage(req.SourceImageId)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.status.current != "Available" {
+		return nil, fakeErr(8044, "Image [%s] is not available", source.ImageId)
+	}
+
+	image := &fakeImage{UHostImageSet: source.UHostImageSet}
+	image.ImageId = s.newId("uimage")
+	image.ImageName = fakeStringValue(req.TargetImageName, source.ImageName)
+	image.ImageDescription = fakeStringValue(req.TargetImageDescription, source.ImageDescription)
+	image.CreateTime = s.now()
+	image.status = newFakeStatus("Copying", "Available")
+
+	s.images = append(s.images, image)
+	return uhost.CopyCustomImageResponse{TargetImageId: image.ImageId}, nil
+}
+
+func (s *fakeUCloudAPI) importCustomImage(q url.Values) (interface{}, error) {
+	req := uhost.ImportCustomImageRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	for name, value := range map[string]*string{"ImageName": req.ImageName, "UFileUrl": req.UFileUrl, "OsType": req.OsType, "OsName": req.OsName, "Format": req.Format} {
+		if value == nil {
+			return nil, fakeMissingParam(name)
+		}
+	}
+
+	if req.Auth == nil || !*req.Auth {
+		return nil, fakeErr(230, "Params [Auth] must be true")
+	}
+
+	image := &fakeImage{}
+	image.ImageId = s.newId("uimage")
+	image.ImageName = *req.ImageName
+	image.ImageDescription = fakeStringValue(req.ImageDescription, "")
+	image.ImageType = "Custom"
+	image.OsType = *req.OsType
+	image.OsName = *req.OsName
+	image.ImageSize = 20
+	image.CreateTime = s.now()
+	image.status = newFakeStatus("Importing", "Available")
+
+	s.images = append(s.images, image)
+	return uhost.ImportCustomImageResponse{ImageId: image.ImageId}, nil
+}
+
+func (s *fakeUCloudAPI) updateImageAttribute(q url.Values) (interface{}, error) {
+	req := updateImageAttributeRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	image, err := s.getCustomImage(req.ImageId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ImageName != nil {
+		image.ImageName = *req.ImageName
+	}
+
+	if req.ImageDescription != nil {
+		image.ImageDescription = *req.ImageDescription
+	}
+	return updateImageAttributeResponse{ImageId: image.ImageId}, nil
+}
+
+func (s *fakeUCloudAPI) terminateCustomImage(q url.Values) (interface{}, error) {
+	req := uhost.TerminateCustomImageRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	image, err := s.getCustomImage(req.ImageId)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range s.images {
+		if item == image {
+			s.images = append(s.images[:i], s.images[i+1:]...)
+			break
+		}
+	}
+	return uhost.TerminateCustomImageResponse{ImageId: image.ImageId}, nil
+}
+
+func (s *fakeUCloudAPI) createUHostInstance(q url.Values) (interface{}, error) {
+	keyPairReq := createUHostInstanceRequest{}
+	if err := fakeDecodeRequest(q, &keyPairReq); err != nil {
+		return nil, err
+	}
+	req := keyPairReq.CreateUHostInstanceRequest
+
+	if req.Zone == nil {
+		return nil, fakeMissingParam("Zone")
+	}
+	if req.ImageId == nil {
+		return nil, fakeMissingParam("ImageId")
+	}
+
+	instance := &fakeInstance{}
+	switch fakeStringValue(req.LoginMode, "Password") {
+	case "Password":
+		if req.Password == nil {
+			return nil, fakeMissingParam("Password")
+		}
+		instance.password = *req.Password
+	case "KeyPair":
+		if keyPairReq.KeyPairId == nil {
+			return nil, fakeMissingParam("KeyPairId")
+		}
+		if _, ok := s.keyPairs[*keyPairReq.KeyPairId]; !ok {
+			return nil, fakeErr(230, "Params [KeyPairId] not available")
+		}
+		instance.keyPairId = *keyPairReq.KeyPairId
+	default:
+		return nil, fakeErr(230, "Params [LoginMode] not available")
+	}
+
+	if req.UserDataScript != nil {
+		if _, err := base64.StdEncoding.DecodeString(*req.UserDataScript); err != nil || len(*req.UserDataScript) > 16*1024 {
+			return nil, fakeErr(230, "Params [UserDataScript] not available")
+		}
+		instance.userData = *req.UserDataScript
+	}
+	if len(req.Disks) == 0 {
+		return nil, fakeMissingParam("Disks")
+	}
+
+	image := s.getImage(*req.ImageId)
+	if image == nil {
+		return nil, fakeErr(8040, "Image [%s] not exist", *req.ImageId)
+	}
+
+	instance.UHostId = s.newId("uhost")
+	instance.Zone = *req.Zone
+	instance.UHostType = fakeStringValue(req.UHostType, "Normal")
+	instance.HostType = fakeStringValue(req.HostType, "N2")
+	instance.StorageType = "LocalDisk"
+	instance.ImageId = image.ImageId
+	instance.BasicImageId = image.ImageId
+	instance.BasicImageName = image.ImageName
+	instance.OsName = image.OsName
+	instance.OsType = image.OsType
+	instance.Name = fakeStringValue(req.Name, "UHost")
+	instance.Tag = fakeStringValue(req.Tag, "Default")
+	instance.ChargeType = fakeStringValue(req.ChargeType, "Month")
+	instance.CPU = fakeIntValue(req.CPU, 4)
+	instance.Memory = fakeIntValue(req.Memory, 8192)
+	instance.GPU = fakeIntValue(req.GPU, 0)
+	instance.NetCapability = fakeStringValue(req.NetCapability, "Normal")
+	instance.TimemachineFeature = "no"
+	if fakeStringValue(req.TimemachineFeature, "No") == "Yes" {
+		instance.TimemachineFeature = "Yes"
+	}
+	instance.AutoRenew = "Yes"
+	instance.BootDiskState = "Normal"
+	instance.LifeCycle = "Normal"
+	instance.CreateTime = s.now()
+	instance.ExpireTime = instance.CreateTime + 30*24*3600
+
+	for i, disk := range req.Disks {
+		if disk.Size == nil || disk.Type == nil || disk.IsBoot == nil {
+			return nil, fakeErr(230, "Params [Disks.%d] not available", i)
+		}
+
+		item := uhost.UHostDiskSet{
+			Type:       "Data",
+			DiskId:     fmt.Sprintf("%s-disk-%d", instance.UHostId, i),
+			Drive:      fmt.Sprintf("vd%c", 'a'+i),
+			Size:       *disk.Size,
+			IsBoot:     *disk.IsBoot,
+			DiskType:   *disk.Type,
+			BackupType: fakeStringValue(disk.BackupType, "NONE"),
+		}
+		if item.IsBoot == "True" {
+			item.Type = "Boot"
+			if item.Size < image.ImageSize {
+				return nil, fakeErr(8041, "Boot disk size must not be smaller than image size %d", image.ImageSize)
+			}
+		}
+		instance.DiskSet = append(instance.DiskSet, item)
+	}
+
+	subnet, err := s.resolveInstanceSubnet(req.VPCId, req.SubnetId)
+	if err != nil {
+		return nil, err
+	}
+	privateIP := ""
+	if len(req.PrivateIp) > 0 {
+		privateIP = req.PrivateIp[0]
+		if err := s.checkPrivateIP(subnet, privateIP); err != nil {
+			return nil, err
+		}
+	} else {
+		privateIP = s.allocPrivateIP(subnet)
+	}
+
+	if req.PrivateMac != nil {
+		if _, err := net.ParseMAC(*req.PrivateMac); err != nil {
+			return nil, fakeErr(230, "Params [PrivateMac] not available")
+		}
+		instance.privateMac = *req.PrivateMac
+	} else {
+		seq := s.newIntId()
+		instance.privateMac = fmt.Sprintf("52:54:00:00:%02x:%02x", (seq>>8)&0xff, seq&0xff)
+	}
+
+	instance.IPSet = []uhost.UHostIPSet{
+		{
+			Type:     "Private",
+			IP:       privateIP,
+			VPCId:    subnet.VPCId,
+			SubnetId: subnet.SubnetId,
+		},
+	}
+
+	if req.SecurityGroupId != nil {
+		fw := s.getFirewallByGroupId(*req.SecurityGroupId)
+		if fw == nil {
+			return nil, fakeErr(54002, "Firewall [%s] not exist", *req.SecurityGroupId)
+		}
+		s.bindings[instance.UHostId] = fw.FWId
+	}
+
+	instance.status = newFakeStatus("Initializing", "Running")
+	s.instances[instance.UHostId] = instance
+
+	return uhost.CreateUHostInstanceResponse{
+		UHostIds: []string{instance.UHostId},
+		IPs:      []string{instance.IPSet[0].IP},
+	}, nil
+}
+
+func (s *fakeUCloudAPI) describeUHostInstance(q url.Values) (interface{}, error) {
+	req := uhost.DescribeUHostInstanceRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	var matched []fakeUHostInstanceSet
+	for _, id := range fakeSortedKeys(s.instances) {
+		instance := s.instances[id]
+		if !fakeInStrings(id, req.UHostIds) {
+			continue
+		}
+		if req.Zone != nil && *req.Zone != instance.Zone {
+			continue
+		}
+		if req.Tag != nil && *req.Tag != instance.Tag {
+			continue
+		}
+
+		instance.State = instance.status.poll()
+		item := fakeUHostInstanceSet{UHostInstanceSet: instance.UHostInstanceSet}
+		for _, ip := range instance.IPSet {
+			ipSet := fakeUHostIPSet{UHostIPSet: ip}
+			if ip.Type == "Private" {
+				ipSet.Mac = instance.privateMac
+			}
+			item.IPSet = append(item.IPSet, ipSet)
+		}
+		matched = append(matched, item)
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	page := matched[start:end]
+	if page == nil {
+		page = []fakeUHostInstanceSet{}
+	}
+	return struct {
+		uhost.DescribeUHostInstanceResponse
+		UHostSet []fakeUHostInstanceSet
+	}{uhost.DescribeUHostInstanceResponse{TotalCount: len(matched)}, page}, nil
+}
+
+func (s *fakeUCloudAPI) startUHostInstance(q url.Values) (interface{}, error) {
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.status.current != "Stopped" {
+		return nil, fakeErr(8010, "UHost [%s] state is %s, expected Stopped", instance.UHostId, instance.status.current)
+	}
+
+	instance.status.to("Starting", "Running")
+	return uhost.StartUHostInstanceResponse{UhostId: instance.UHostId}, nil
+}
+
+func (s *fakeUCloudAPI) stopUHostInstance(q url.Values) (interface{}, error) {
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	switch instance.status.current {
+	case "Stopped", "Stopping":
+	case "Running":
+		instance.status.to("Stopping", "Stopped")
+	default:
+		return nil, fakeErr(8010, "UHost [%s] state is %s, expected Running", instance.UHostId, instance.status.current)
+	}
+
+	return uhost.StopUHostInstanceResponse{UhostId: instance.UHostId}, nil
+}
+
+func (s *fakeUCloudAPI) rebootUHostInstance(q url.Values) (interface{}, error) {
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.status.current != "Running" {
+		return nil, fakeErr(8010, "UHost [%s] state is %s, expected Running", instance.UHostId, instance.status.current)
+	}
+
+	instance.status.to("Rebooting", "Running")
+	return uhost.RebootUHostInstanceResponse{UhostId: instance.UHostId}, nil
+}
+
+func (s *fakeUCloudAPI) resizeUHostInstance(q url.Values) (interface{}, error) {
+	req := uhost.ResizeUHostInstanceRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.status.current != "Stopped" {
+		return nil, fakeErr(8010, "UHost [%s] must be stopped before resize", instance.UHostId)
+	}
+
+	if req.CPU != nil {
+		instance.CPU = *req.CPU
+	}
+	if req.Memory != nil {
+		instance.Memory = *req.Memory
+	}
+
+	for i, disk := range instance.DiskSet {
+		var size *int
+		if disk.IsBoot == "True" {
+			size = req.BootDiskSpace
+		} else if disk.Type == "Data" && strings.HasPrefix(disk.DiskType, "LOCAL_") {
+			size = req.DiskSpace
+		}
+
+		if size == nil {
+			continue
+		}
+		if *size < disk.Size {
+			return nil, fakeErr(8042, "Disk [%s] can not be shrunk from %d to %d", disk.DiskId, disk.Size, *size)
+		}
+		instance.DiskSet[i].Size = *size
+	}
+
+	return uhost.ResizeUHostInstanceResponse{UhostId: instance.UHostId}, nil
+}
+
+func (s *fakeUCloudAPI) resetUHostInstancePassword(q url.Values) (interface{}, error) {
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Get("Password") == "" {
+		return nil, fakeMissingParam("Password")
+	}
+
+	if instance.keyPairId != "" {
+		return nil, fakeErr(8012, "UHost [%s] is logged in by key pair, the password cannot be reset", instance.UHostId)
+	}
+
+	if instance.status.current != "Stopped" {
+		return nil, fakeErr(8010, "UHost [%s] must be stopped before reset password", instance.UHostId)
+	}
+
+	instance.password = q.Get("Password")
+	return uhost.ResetUHostInstancePasswordResponse{UhostId: instance.UHostId}, nil
+}
+
+func (s *fakeUCloudAPI) reinstallUHostInstance(q url.Values) (interface{}, error) {
+	req := uhost.ReinstallUHostInstanceRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.status.current != "Stopped" {
+		return nil, fakeErr(8010, "UHost [%s] must be stopped before reinstall", instance.UHostId)
+	}
+
+	// the login mode cannot be changed by reinstall
+	if instance.keyPairId != "" {
+		if req.Password != nil {
+			return nil, fakeErr(230, "Params [Password] not available")
+		}
+	} else if req.Password == nil || *req.Password == "" {
+		return nil, fakeMissingParam("Password")
+	}
+
+	image := s.getImage(fakeStringValue(req.ImageId, instance.BasicImageId))
+	if image == nil {
+		return nil, fakeErr(8040, "Image [%s] not exist", *req.ImageId)
+	}
+
+	if image.OsType != instance.OsType && fakeStringValue(req.ReserveDisk, "Yes") == "Yes" {
+		return nil, fakeErr(8043, "UHost [%s] can not reserve disk when reinstall from %s to %s", instance.UHostId, instance.OsType, image.OsType)
+	}
+
+	if req.Password != nil {
+		instance.password = *req.Password
+	}
+	instance.BasicImageId = image.ImageId
+	instance.BasicImageName = image.ImageName
+	instance.OsName = image.OsName
+	instance.OsType = image.OsType
+	instance.status.to("Install", "Running")
+
+	return uhost.ReinstallUHostInstanceResponse{UhostId: instance.UHostId}, nil
+}
+
+func (s *fakeUCloudAPI) upgradeToArkUHostInstance(q url.Values) (interface{}, error) {
+	req := uhost.UpgradeToArkUHostInstanceRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if len(req.UHostIds) == 0 {
+		return nil, fakeMissingParam("UHostIds")
+	}
+
+	for _, id := range req.UHostIds {
+		instance, ok := s.instances[id]
+		if !ok {
+			return nil, fakeErr(8039, "UHost [%s] not exist", id)
+		}
+
+		if instance.status.current != "Stopped" {
+			return nil, fakeErr(8010, "UHost [%s] must be stopped before upgrade to ark", instance.UHostId)
+		}
+
+		if instance.TimemachineFeature == "Yes" {
+			return nil, fakeErr(8044, "UHost [%s] has been upgraded to ark", instance.UHostId)
+		}
+	}
+
+	for _, id := range req.UHostIds {
+		instance := s.instances[id]
+		instance.TimemachineFeature = "Yes"
+		instance.status.to("Upgrading", "Stopped")
+	}
+
+	return uhost.UpgradeToArkUHostInstanceResponse{UHostSet: req.UHostIds}, nil
+}
+
+func (s *fakeUCloudAPI) modifyUHostInstanceName(q url.Values) (interface{}, error) {
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	instance.Name = q.Get("Name")
+	return uhost.ModifyUHostInstanceNameResponse{UhostId: instance.UHostId}, nil
+}
+
+func (s *fakeUCloudAPI) modifyUHostInstanceTag(q url.Values) (interface{}, error) {
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	instance.Tag = q.Get("Tag")
+	if instance.Tag == "" {
+		instance.Tag = "Default"
+	}
+	return uhost.ModifyUHostInstanceTagResponse{UhostId: instance.UHostId}, nil
+}
+
+func (s *fakeUCloudAPI) modifyUHostInstanceRemark(q url.Values) (interface{}, error) {
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	instance.Remark = q.Get("Remark")
+	return uhost.ModifyUHostInstanceRemarkResponse{UhostId: instance.UHostId}, nil
+}
+
+func (s *fakeUCloudAPI) terminateUHostInstance(q url.Values) (interface{}, error) {
+	instance, err := s.getInstance(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.status.current != "Stopped" {
+		return nil, fakeErr(8010, "UHost [%s] must be stopped before terminate", instance.UHostId)
+	}
+
+	// the attached udisks are detached but not released
+	for _, disk := range s.disks {
+		if disk.UHostId == instance.UHostId {
+			s.detachDisk(disk, instance)
+			disk.status.set("Available")
+		}
+	}
+
+	for _, eip := range s.eips {
+		if eip.Resource.ResourceId == instance.UHostId {
+			s.unbindEIP(eip)
+		}
+	}
+
+	for _, lb := range s.lbs {
+		lb.releaseBackendsOf(instance.UHostId)
+	}
+
+	delete(s.bindings, instance.UHostId)
+	delete(s.instances, instance.UHostId)
+	return uhost.TerminateUHostInstanceResponse{UHostId: instance.UHostId, InRecycle: "No"}, nil
+}
+
+func (s *fakeUCloudAPI) importUHostKeyPairs(q url.Values) (interface{}, error) {
+	req := importUHostKeyPairsRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.KeyPairName == nil {
+		return nil, fakeMissingParam("KeyPairName")
+	}
+	if req.PublicKeyBody == nil {
+		return nil, fakeMissingParam("PublicKeyBody")
+	}
+
+	fields := strings.Fields(*req.PublicKeyBody)
+	if len(fields) < 2 {
+		return nil, fakeErr(230, "Params [PublicKeyBody] not available")
+	}
+	body, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, fakeErr(230, "Params [PublicKeyBody] not available")
+	}
+
+	for _, keyPair := range s.keyPairs {
+		if keyPair.KeyPairName == *req.KeyPairName {
+			return nil, fakeErr(8013, "KeyPair [%s] already exists", *req.KeyPairName)
+		}
+	}
+
+	var fingerprint []string
+	for _, b := range md5.Sum(body) {
+		fingerprint = append(fingerprint, fmt.Sprintf("%02x", b))
+	}
+
+	keyPair := &fakeKeyPair{publicKey: *req.PublicKeyBody}
+	keyPair.KeyPairId = s.newId("uhostkp")
+	keyPair.KeyPairName = *req.KeyPairName
+	keyPair.KeyPairFingerPrint = strings.Join(fingerprint, ":")
+	keyPair.ProjectId = fakeStringValue(req.ProjectId, "org-fake")
+	keyPair.CreateTime = s.now()
+	s.keyPairs[keyPair.KeyPairId] = keyPair
+
+	return importUHostKeyPairsResponse{KeyPair: keyPair.uhostKeyPair}, nil
+}
+
+func (s *fakeUCloudAPI) describeUHostKeyPairs(q url.Values) (interface{}, error) {
+	req := describeUHostKeyPairsRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	matched := []uhostKeyPair{}
+	for _, id := range fakeSortedKeys(s.keyPairs) {
+		keyPair := s.keyPairs[id]
+		if req.KeyPairName != nil && *req.KeyPairName != keyPair.KeyPairName {
+			continue
+		}
+		matched = append(matched, keyPair.uhostKeyPair)
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	return describeUHostKeyPairsResponse{TotalCount: len(matched), KeyPairs: matched[start:end]}, nil
+}
+
+func (s *fakeUCloudAPI) deleteUHostKeyPairs(q url.Values) (interface{}, error) {
+	req := deleteUHostKeyPairsRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if len(req.KeyPairIds) == 0 {
+		return nil, fakeMissingParam("KeyPairIds")
+	}
+
+	for _, id := range req.KeyPairIds {
+		if _, ok := s.keyPairs[id]; !ok {
+			return nil, fakeErr(8039, "KeyPair [%s] not exist", id)
+		}
+	}
+
+	for _, id := range req.KeyPairIds {
+		delete(s.keyPairs, id)
+	}
+	return nil, nil
+}
@@ -0,0 +1,538 @@
+package ucloud
+
+import (
+	"net/url"
+
+	"github.com/ucloud/ucloud-sdk-go/services/ulb"
+)
+
+type fakeLB struct {
+	ulb.ULBSet
+	vservers map[string]*fakeVServer
+}
+
+type fakeVServer struct {
+	ulb.ULBVServerSet
+}
+
+func (s *fakeUCloudAPI) registerULB() {
+	s.handle("CreateULB", s.createULB)
+	s.handle("DescribeULB", s.describeULB)
+	s.handle("UpdateULBAttribute", s.updateULBAttribute)
+	s.handle("DeleteULB", s.deleteULB)
+	s.handle("CreateVServer", s.createVServer)
+	s.handle("DescribeVServer", s.describeVServer)
+	s.handle("UpdateVServerAttribute", s.updateVServerAttribute)
+	s.handle("DeleteVServer", s.deleteVServer)
+	s.handle("AllocateBackend", s.allocateBackend)
+	s.handle("UpdateBackendAttribute", s.updateBackendAttribute)
+	s.handle("ReleaseBackend", s.releaseBackend)
+	s.handle("CreatePolicy", s.createPolicy)
+	s.handle("UpdatePolicy", s.updatePolicy)
+	s.handle("DeletePolicy", s.deletePolicy)
+}
+
+func (lb *fakeLB) bindEIP(eip *fakeEIP) {
+	lb.IPSet = append(lb.IPSet, ulb.ULBIPSet{
+		OperatorName: eip.EIPAddr[0].OperatorName,
+		EIP:          eip.EIPAddr[0].IP,
+		EIPId:        eip.EIPId,
+	})
+}
+
+func (lb *fakeLB) unbindEIP(eip *fakeEIP) {
+	var ipSet []ulb.ULBIPSet
+	for _, item := range lb.IPSet {
+		if item.EIPId != eip.EIPId {
+			ipSet = append(ipSet, item)
+		}
+	}
+	lb.IPSet = ipSet
+}
+
+// releaseBackendsOf will release all of the backends of the resource, it is used by terminating the resource
+func (lb *fakeLB) releaseBackendsOf(resourceId string) {
+	for _, vserver := range lb.vservers {
+		var backends []ulb.ULBBackendSet
+		for _, backend := range vserver.BackendSet {
+			if backend.ResourceId == resourceId {
+				vserver.removeBackendFromPolicies(backend.BackendId)
+				continue
+			}
+			backends = append(backends, backend)
+		}
+		vserver.BackendSet = backends
+	}
+}
+
+func (lb *fakeLB) findBackend(backendId string) (*fakeVServer, int) {
+	for _, vserver := range lb.vservers {
+		for i, backend := range vserver.BackendSet {
+			if backend.BackendId == backendId {
+				return vserver, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+func (vserver *fakeVServer) removeBackendFromPolicies(backendId string) {
+	for i, policy := range vserver.PolicySet {
+		var backends []ulb.PolicyBackendSet
+		for _, backend := range policy.BackendSet {
+			if backend.BackendId != backendId {
+				backends = append(backends, backend)
+			}
+		}
+		vserver.PolicySet[i].BackendSet = backends
+		vserver.PolicySet[i].TotalCount = len(backends)
+	}
+}
+
+func (s *fakeUCloudAPI) getLB(q url.Values) (*fakeLB, error) {
+	id := q.Get("ULBId")
+	if id == "" {
+		return nil, fakeMissingParam("ULBId")
+	}
+
+	lb, ok := s.lbs[id]
+	if !ok {
+		return nil, fakeErr(4086, "ULB [%s] not exist", id)
+	}
+	return lb, nil
+}
+
+func (s *fakeUCloudAPI) getVServer(q url.Values) (*fakeLB, *fakeVServer, error) {
+	lb, err := s.getLB(q)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id := q.Get("VServerId")
+	if id == "" {
+		return nil, nil, fakeMissingParam("VServerId")
+	}
+
+	vserver, ok := lb.vservers[id]
+	if !ok {
+		return nil, nil, fakeErr(4103, "VServer [%s] not exist", id)
+	}
+	return lb, vserver, nil
+}
+
+// snapshot returns the lb with the nested vservers as the describe response
+func (lb *fakeLB) snapshot() ulb.ULBSet {
+	item := lb.ULBSet
+	item.VServerSet = []ulb.ULBVServerSet{}
+	for _, id := range fakeSortedKeys(lb.vservers) {
+		item.VServerSet = append(item.VServerSet, lb.vservers[id].ULBVServerSet)
+	}
+	return item
+}
+
+func (s *fakeUCloudAPI) createULB(q url.Values) (interface{}, error) {
+	req := ulb.CreateULBRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	subnet, err := s.resolveInstanceSubnet(req.VPCId, req.SubnetId)
+	if err != nil {
+		return nil, err
+	}
+
+	lb := &fakeLB{vservers: map[string]*fakeVServer{}}
+	lb.ULBId = s.newId("ulb")
+	lb.Name = fakeStringValue(req.ULBName, "ULB")
+	lb.ULBName = lb.Name
+	lb.Tag = fakeStringValue(req.Tag, "Default")
+	lb.Remark = fakeStringValue(req.Remark, "")
+	lb.CreateTime = s.now()
+	lb.ExpireTime = lb.CreateTime + 30*24*3600
+	lb.VPCId = subnet.VPCId
+	lb.SubnetId = subnet.SubnetId
+	lb.IPSet = []ulb.ULBIPSet{}
+	lb.Resource = []string{}
+
+	if fakeStringValue(req.InnerMode, "No") == "Yes" {
+		lb.ULBType = "InnerMode"
+		lb.PrivateIP = s.allocPrivateIP(subnet)
+	} else {
+		lb.ULBType = "OuterMode"
+	}
+	s.lbs[lb.ULBId] = lb
+
+	return ulb.CreateULBResponse{ULBId: lb.ULBId}, nil
+}
+
+func (s *fakeUCloudAPI) describeULB(q url.Values) (interface{}, error) {
+	req := ulb.DescribeULBRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.ULBId != nil {
+		if _, ok := s.lbs[*req.ULBId]; !ok {
+			return nil, fakeErr(4086, "ULB [%s] not exist", *req.ULBId)
+		}
+	}
+
+	var matched []ulb.ULBSet
+	for _, id := range fakeSortedKeys(s.lbs) {
+		lb := s.lbs[id]
+		if req.ULBId != nil && *req.ULBId != id {
+			continue
+		}
+		if req.VPCId != nil && *req.VPCId != lb.VPCId {
+			continue
+		}
+		if req.SubnetId != nil && *req.SubnetId != lb.SubnetId {
+			continue
+		}
+		matched = append(matched, lb.snapshot())
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	resp := ulb.DescribeULBResponse{TotalCount: len(matched), DataSet: matched[start:end]}
+	if resp.DataSet == nil {
+		resp.DataSet = []ulb.ULBSet{}
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) updateULBAttribute(q url.Values) (interface{}, error) {
+	lb, err := s.getLB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := q["Name"]; ok {
+		lb.Name = v[0]
+		lb.ULBName = v[0]
+	}
+	if v, ok := q["Tag"]; ok {
+		lb.Tag = v[0]
+	}
+	if v, ok := q["Remark"]; ok {
+		lb.Remark = v[0]
+	}
+	return ulb.UpdateULBAttributeResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) deleteULB(q url.Values) (interface{}, error) {
+	lb, err := s.getLB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, eip := range s.eips {
+		if eip.Resource.ResourceId == lb.ULBId {
+			s.unbindEIP(eip)
+		}
+	}
+
+	delete(s.lbs, lb.ULBId)
+	return ulb.DeleteULBResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) createVServer(q url.Values) (interface{}, error) {
+	req := ulb.CreateVServerRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	lb, err := s.getLB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	vserver := &fakeVServer{}
+	vserver.VServerId = s.newId("vserver")
+	vserver.VServerName = fakeStringValue(req.VServerName, "VServer")
+	vserver.ListenType = fakeStringValue(req.ListenType, "RequestProxy")
+	vserver.Protocol = fakeStringValue(req.Protocol, "HTTP")
+	vserver.FrontendPort = fakeIntValue(req.FrontendPort, 80)
+	vserver.Method = fakeStringValue(req.Method, "Roundrobin")
+	vserver.PersistenceType = fakeStringValue(req.PersistenceType, "None")
+	vserver.PersistenceInfo = fakeStringValue(req.PersistenceInfo, "")
+	vserver.ClientTimeout = fakeIntValue(req.ClientTimeout, 60)
+	vserver.MonitorType = fakeStringValue(req.MonitorType, "Port")
+	vserver.Domain = fakeStringValue(req.Domain, "")
+	vserver.Path = fakeStringValue(req.Path, "")
+	vserver.SSLSet = []ulb.ULBSSLSet{}
+	vserver.BackendSet = []ulb.ULBBackendSet{}
+	vserver.PolicySet = []ulb.ULBPolicySet{}
+
+	for _, other := range lb.vservers {
+		if other.FrontendPort == vserver.FrontendPort {
+			return nil, fakeErr(4104, "Port [%d] has been used by VServer [%s]", vserver.FrontendPort, other.VServerId)
+		}
+	}
+
+	lb.vservers[vserver.VServerId] = vserver
+	return ulb.CreateVServerResponse{VServerId: vserver.VServerId}, nil
+}
+
+func (s *fakeUCloudAPI) describeVServer(q url.Values) (interface{}, error) {
+	req := ulb.DescribeVServerRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	// the vserver is not found if the ulb has been deleted
+	lb, err := s.getLB(q)
+	if err != nil && req.VServerId != nil {
+		return nil, fakeErr(4103, "VServer [%s] not exist", *req.VServerId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []ulb.ULBVServerSet
+	if req.VServerId != nil {
+		vserver, ok := lb.vservers[*req.VServerId]
+		if !ok {
+			return nil, fakeErr(4103, "VServer [%s] not exist", *req.VServerId)
+		}
+		matched = append(matched, vserver.ULBVServerSet)
+	} else {
+		for _, id := range fakeSortedKeys(lb.vservers) {
+			matched = append(matched, lb.vservers[id].ULBVServerSet)
+		}
+	}
+
+	limit, offset := fakeStringPageArgs(req.Limit, req.Offset)
+	start, end := fakePage(len(matched), limit, offset, 20)
+	resp := ulb.DescribeVServerResponse{TotalCount: len(matched), DataSet: matched[start:end]}
+	if resp.DataSet == nil {
+		resp.DataSet = []ulb.ULBVServerSet{}
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) updateVServerAttribute(q url.Values) (interface{}, error) {
+	req := ulb.UpdateVServerAttributeRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	_, vserver, err := s.getVServer(q)
+	if err != nil {
+		return nil, err
+	}
+
+	vserver.VServerName = fakeStringValue(req.VServerName, vserver.VServerName)
+	vserver.Protocol = fakeStringValue(req.Protocol, vserver.Protocol)
+	vserver.Method = fakeStringValue(req.Method, vserver.Method)
+	vserver.PersistenceType = fakeStringValue(req.PersistenceType, vserver.PersistenceType)
+	vserver.PersistenceInfo = fakeStringValue(req.PersistenceInfo, vserver.PersistenceInfo)
+	vserver.ClientTimeout = fakeIntValue(req.ClientTimeout, vserver.ClientTimeout)
+	vserver.MonitorType = fakeStringValue(req.MonitorType, vserver.MonitorType)
+	if req.Domain != nil {
+		vserver.Domain = *req.Domain
+	}
+	if req.Path != nil {
+		vserver.Path = *req.Path
+	}
+	return ulb.UpdateVServerAttributeResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) deleteVServer(q url.Values) (interface{}, error) {
+	lb, vserver, err := s.getVServer(q)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(lb.vservers, vserver.VServerId)
+	return ulb.DeleteVServerResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) allocateBackend(q url.Values) (interface{}, error) {
+	req := ulb.AllocateBackendRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	_, vserver, err := s.getVServer(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if fakeStringValue(req.ResourceType, "") != "UHost" {
+		return nil, fakeErr(230, "Params [ResourceType] not available")
+	}
+
+	resourceId := fakeStringValue(req.ResourceId, "")
+	instance, ok := s.instances[resourceId]
+	if !ok {
+		return nil, fakeErr(8039, "UHost [%s] not exist", resourceId)
+	}
+
+	backend := ulb.ULBBackendSet{
+		BackendId:    s.newId("backend"),
+		ResourceType: "UHost",
+		ResourceId:   resourceId,
+		ResourceName: instance.Name,
+		PrivateIP:    instance.IPSet[0].IP,
+		SubnetId:     instance.IPSet[0].SubnetId,
+		Port:         fakeIntValue(req.Port, 80),
+		Enabled:      fakeIntValue(req.Enabled, 1),
+	}
+	vserver.BackendSet = append(vserver.BackendSet, backend)
+
+	return ulb.AllocateBackendResponse{BackendId: backend.BackendId}, nil
+}
+
+func (s *fakeUCloudAPI) updateBackendAttribute(q url.Values) (interface{}, error) {
+	req := ulb.UpdateBackendAttributeRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	lb, err := s.getLB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	vserver, i := lb.findBackend(q.Get("BackendId"))
+	if vserver == nil {
+		return nil, fakeErr(4105, "Backend [%s] not exist", q.Get("BackendId"))
+	}
+
+	backend := &vserver.BackendSet[i]
+	backend.Port = fakeIntValue(req.Port, backend.Port)
+	backend.Enabled = fakeIntValue(req.Enabled, backend.Enabled)
+	return ulb.UpdateBackendAttributeResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) releaseBackend(q url.Values) (interface{}, error) {
+	lb, err := s.getLB(q)
+	if err != nil {
+		return nil, err
+	}
+
+	backendId := q.Get("BackendId")
+	vserver, i := lb.findBackend(backendId)
+	if vserver == nil {
+		return nil, fakeErr(4105, "Backend [%s] not exist", backendId)
+	}
+
+	vserver.BackendSet = append(vserver.BackendSet[:i], vserver.BackendSet[i+1:]...)
+	vserver.removeBackendFromPolicies(backendId)
+	return ulb.ReleaseBackendResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) buildPolicy(vserver *fakeVServer, backendIds []string, policyType, match *string) (*ulb.ULBPolicySet, error) {
+	if len(backendIds) == 0 {
+		return nil, fakeMissingParam("BackendId")
+	}
+	if match == nil {
+		return nil, fakeMissingParam("Match")
+	}
+
+	policy := &ulb.ULBPolicySet{
+		PolicyType: "Custom",
+		Type:       fakeStringValue(policyType, "Domain"),
+		Match:      *match,
+		VServerId:  vserver.VServerId,
+	}
+
+	for _, id := range backendIds {
+		var found *ulb.ULBBackendSet
+		for i := range vserver.BackendSet {
+			if vserver.BackendSet[i].BackendId == id {
+				found = &vserver.BackendSet[i]
+			}
+		}
+		if found == nil {
+			return nil, fakeErr(4105, "Backend [%s] not exist", id)
+		}
+
+		policy.BackendSet = append(policy.BackendSet, ulb.PolicyBackendSet{
+			BackendId:    found.BackendId,
+			ObjectId:     found.ResourceId,
+			Port:         found.Port,
+			PrivateIP:    found.PrivateIP,
+			ResourceName: found.ResourceName,
+		})
+	}
+	policy.TotalCount = len(policy.BackendSet)
+	return policy, nil
+}
+
+func (s *fakeUCloudAPI) createPolicy(q url.Values) (interface{}, error) {
+	req := ulb.CreatePolicyRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	_, vserver, err := s.getVServer(q)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.buildPolicy(vserver, req.BackendId, req.Type, req.Match)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.PolicyId = s.newId("policy")
+	policy.PolicyPriority = len(vserver.PolicySet) + 1
+	vserver.PolicySet = append(vserver.PolicySet, *policy)
+	return ulb.CreatePolicyResponse{PolicyId: policy.PolicyId}, nil
+}
+
+func (s *fakeUCloudAPI) updatePolicy(q url.Values) (interface{}, error) {
+	req := ulb.UpdatePolicyRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	_, vserver, err := s.getVServer(q)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range vserver.PolicySet {
+		if item.PolicyId != q.Get("PolicyId") {
+			continue
+		}
+
+		policy, err := s.buildPolicy(vserver, req.BackendId, req.Type, req.Match)
+		if err != nil {
+			return nil, err
+		}
+
+		policy.PolicyId = item.PolicyId
+		policy.PolicyPriority = item.PolicyPriority
+		vserver.PolicySet[i] = *policy
+		return ulb.UpdatePolicyResponse{PolicyId: policy.PolicyId}, nil
+	}
+
+	return nil, fakeErr(4106, "Policy [%s] not exist", q.Get("PolicyId"))
+}
+
+func (s *fakeUCloudAPI) deletePolicy(q url.Values) (interface{}, error) {
+	policyId := q.Get("PolicyId")
+	if policyId == "" {
+		return nil, fakeMissingParam("PolicyId")
+	}
+
+	// the policy can be deleted without lb id
+	for _, lb := range s.lbs {
+		for _, vserver := range lb.vservers {
+			if q.Get("VServerId") != "" && q.Get("VServerId") != vserver.VServerId {
+				continue
+			}
+
+			for i, item := range vserver.PolicySet {
+				if item.PolicyId == policyId {
+					vserver.PolicySet = append(vserver.PolicySet[:i], vserver.PolicySet[i+1:]...)
+					return ulb.DeletePolicyResponse{}, nil
+				}
+			}
+		}
+	}
+
+	return nil, fakeErr(4106, "Policy [%s] not exist", policyId)
+}
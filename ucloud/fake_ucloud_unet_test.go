@@ -0,0 +1,457 @@
+package ucloud
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ucloud/ucloud-sdk-go/services/uhost"
+	"github.com/ucloud/ucloud-sdk-go/services/unet"
+)
+
+type fakeEIP struct {
+	unet.UnetEIPSet
+}
+
+type fakeFirewall struct {
+	unet.FirewallDataSet
+}
+
+func (s *fakeUCloudAPI) registerUNet() {
+	s.handle("AllocateEIP", s.allocateEIP)
+	s.handle("DescribeEIP", s.describeEIP)
+	s.handle("UpdateEIPAttribute", s.updateEIPAttribute)
+	s.handle("SetEIPPayMode", s.setEIPPayMode)
+	s.handle("ModifyEIPBandwidth", s.modifyEIPBandwidth)
+	s.handle("BindEIP", s.bindEIP)
+	s.handle("UnBindEIP", s.unBindEIP)
+	s.handle("ReleaseEIP", s.releaseEIP)
+
+	s.handle("CreateFirewall", s.createFirewall)
+	s.handle("DescribeFirewall", s.describeFirewall)
+	s.handle("UpdateFirewall", s.updateFirewall)
+	s.handle("UpdateFirewallAttribute", s.updateFirewallAttribute)
+	s.handle("GrantFirewall", s.grantFirewall)
+	s.handle("DeleteFirewall", s.deleteFirewall)
+}
+
+func (s *fakeUCloudAPI) getEIP(q url.Values) (*fakeEIP, error) {
+	id := q.Get("EIPId")
+	if id == "" {
+		return nil, fakeMissingParam("EIPId")
+	}
+
+	eip, ok := s.eips[id]
+	if !ok {
+		return nil, fakeErr(8036, "EIP [%s] not exist", id)
+	}
+	return eip, nil
+}
+
+func (s *fakeUCloudAPI) allocateEIP(q url.Values) (interface{}, error) {
+	req := unet.AllocateEIPRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.OperatorName == nil {
+		return nil, fakeMissingParam("OperatorName")
+	}
+	if req.Bandwidth == nil {
+		return nil, fakeMissingParam("Bandwidth")
+	}
+
+	eip := &fakeEIP{}
+	eip.EIPId = s.newId("eip")
+	eip.Bandwidth = *req.Bandwidth
+	eip.Status = "free"
+	eip.ChargeType = fakeStringValue(req.ChargeType, "Month")
+	eip.PayMode = fakeStringValue(req.PayMode, "Bandwidth")
+	eip.Name = fakeStringValue(req.Name, "EIP")
+	eip.Tag = fakeStringValue(req.Tag, "Default")
+	eip.Remark = fakeStringValue(req.Remark, "")
+	eip.CreateTime = s.now()
+	eip.ExpireTime = eip.CreateTime + 30*24*3600
+	eip.EIPAddr = []unet.UnetEIPAddrSet{
+		{OperatorName: *req.OperatorName, IP: "106.75.0." + strconv.Itoa(s.seq%250+1)},
+	}
+	s.eips[eip.EIPId] = eip
+
+	return unet.AllocateEIPResponse{
+		EIPSet: []unet.UnetAllocateEIPSet{{EIPId: eip.EIPId, EIPAddr: eip.EIPAddr}},
+	}, nil
+}
+
+func (s *fakeUCloudAPI) describeEIP(q url.Values) (interface{}, error) {
+	req := unet.DescribeEIPRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	var matched []unet.UnetEIPSet
+	for _, id := range fakeSortedKeys(s.eips) {
+		if !fakeInStrings(id, req.EIPIds) {
+			continue
+		}
+		matched = append(matched, s.eips[id].UnetEIPSet)
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	resp := unet.DescribeEIPResponse{TotalCount: len(matched), EIPSet: matched[start:end]}
+	if resp.EIPSet == nil {
+		resp.EIPSet = []unet.UnetEIPSet{}
+	}
+	for _, eip := range resp.EIPSet {
+		resp.TotalBandwidth += eip.Bandwidth
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) updateEIPAttribute(q url.Values) (interface{}, error) {
+	eip, err := s.getEIP(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := q["Name"]; ok {
+		eip.Name = v[0]
+	}
+	if v, ok := q["Tag"]; ok {
+		eip.Tag = v[0]
+	}
+	if v, ok := q["Remark"]; ok {
+		eip.Remark = v[0]
+	}
+	return unet.UpdateEIPAttributeResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) setEIPPayMode(q url.Values) (interface{}, error) {
+	req := unet.SetEIPPayModeRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	eip, err := s.getEIP(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PayMode == nil {
+		return nil, fakeMissingParam("PayMode")
+	}
+	if req.Bandwidth == nil {
+		return nil, fakeMissingParam("Bandwidth")
+	}
+
+	eip.PayMode = *req.PayMode
+	eip.Bandwidth = *req.Bandwidth
+	return unet.SetEIPPayModeResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) modifyEIPBandwidth(q url.Values) (interface{}, error) {
+	req := unet.ModifyEIPBandwidthRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	eip, err := s.getEIP(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Bandwidth == nil {
+		return nil, fakeMissingParam("Bandwidth")
+	}
+
+	eip.Bandwidth = *req.Bandwidth
+	return unet.ModifyEIPBandwidthResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) bindEIP(q url.Values) (interface{}, error) {
+	eip, err := s.getEIP(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if eip.Status != "free" {
+		return nil, fakeErr(8037, "EIP [%s] has been bound", eip.EIPId)
+	}
+
+	resourceType, resourceId := q.Get("ResourceType"), q.Get("ResourceId")
+	var resourceName string
+	switch resourceType {
+	case "uhost":
+		instance, ok := s.instances[resourceId]
+		if !ok {
+			return nil, fakeErr(8039, "UHost [%s] not exist", resourceId)
+		}
+		resourceName = instance.Name
+		instance.IPSet = append(instance.IPSet, s.eipIPSet(eip))
+	case "ulb":
+		lb, ok := s.lbs[resourceId]
+		if !ok {
+			return nil, fakeErr(4086, "ULB [%s] not exist", resourceId)
+		}
+		resourceName = lb.Name
+		lb.bindEIP(eip)
+	default:
+		return nil, fakeErr(230, "Params [ResourceType] not available")
+	}
+
+	eip.Status = "used"
+	eip.Resource = unet.UnetEIPResourceSet{
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		ResourceName: resourceName,
+		EIPId:        eip.EIPId,
+	}
+	return unet.BindEIPResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) eipIPSet(eip *fakeEIP) (item uhost.UHostIPSet) {
+	item.Type = eip.EIPAddr[0].OperatorName
+	item.IPId = eip.EIPId
+	item.IP = eip.EIPAddr[0].IP
+	item.Bandwidth = eip.Bandwidth
+	return item
+}
+
+func (s *fakeUCloudAPI) unBindEIP(q url.Values) (interface{}, error) {
+	eip, err := s.getEIP(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if eip.Status != "used" || eip.Resource.ResourceId != q.Get("ResourceId") || eip.Resource.ResourceType != q.Get("ResourceType") {
+		return nil, fakeErr(8038, "EIP [%s] is not bound to [%s]", eip.EIPId, q.Get("ResourceId"))
+	}
+
+	s.unbindEIP(eip)
+	return unet.UnBindEIPResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) unbindEIP(eip *fakeEIP) {
+	if instance, ok := s.instances[eip.Resource.ResourceId]; ok {
+		var ipSet []uhost.UHostIPSet
+		for _, item := range instance.IPSet {
+			if item.IPId != eip.EIPId {
+				ipSet = append(ipSet, item)
+			}
+		}
+		instance.IPSet = ipSet
+	}
+
+	if lb, ok := s.lbs[eip.Resource.ResourceId]; ok {
+		lb.unbindEIP(eip)
+	}
+
+	eip.Status = "free"
+	eip.Resource = unet.UnetEIPResourceSet{}
+}
+
+func (s *fakeUCloudAPI) releaseEIP(q url.Values) (interface{}, error) {
+	eip, err := s.getEIP(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if eip.Status != "free" {
+		return nil, fakeErr(8037, "EIP [%s] is in use", eip.EIPId)
+	}
+
+	delete(s.eips, eip.EIPId)
+	return unet.ReleaseEIPResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) getFirewall(q url.Values) (*fakeFirewall, error) {
+	id := q.Get("FWId")
+	if id == "" {
+		return nil, fakeMissingParam("FWId")
+	}
+
+	fw, ok := s.firewalls[id]
+	if !ok {
+		return nil, fakeErr(54002, "Firewall [%s] not exist", id)
+	}
+	return fw, nil
+}
+
+func (s *fakeUCloudAPI) getFirewallByGroupId(groupId string) *fakeFirewall {
+	for _, fw := range s.firewalls {
+		if fw.GroupId == groupId || fw.FWId == groupId {
+			return fw
+		}
+	}
+	return nil
+}
+
+// parseFakeFirewallRules parses the rules as "Protocol|Port|SrcIP|Action|Priority"
+func parseFakeFirewallRules(rules []string) ([]unet.FirewallRuleSet, error) {
+	var ruleSet []unet.FirewallRuleSet
+	for i, rule := range rules {
+		parts := strings.Split(rule, "|")
+		if len(parts) != 5 {
+			return nil, fakeErr(230, "Params [Rule.%d] not available", i)
+		}
+
+		ruleSet = append(ruleSet, unet.FirewallRuleSet{
+			ProtocolType: parts[0],
+			DstPort:      parts[1],
+			SrcIP:        parts[2],
+			RuleAction:   parts[3],
+			Priority:     parts[4],
+		})
+	}
+	return ruleSet, nil
+}
+
+func (s *fakeUCloudAPI) createFirewall(q url.Values) (interface{}, error) {
+	req := unet.CreateFirewallRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if len(req.Rule) == 0 {
+		return nil, fakeMissingParam("Rule")
+	}
+
+	rules, err := parseFakeFirewallRules(req.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &fakeFirewall{}
+	fw.FWId = s.newId("firewall")
+	fw.GroupId = strconv.Itoa(s.newIntId())
+	fw.Name = fakeStringValue(req.Name, "Firewall")
+	fw.Tag = fakeStringValue(req.Tag, "Default")
+	fw.Remark = fakeStringValue(req.Remark, "")
+	fw.Type = "user defined"
+	fw.CreateTime = s.now()
+	fw.Rule = rules
+	s.firewalls[fw.FWId] = fw
+
+	return unet.CreateFirewallResponse{FWId: fw.FWId}, nil
+}
+
+func (s *fakeUCloudAPI) describeFirewall(q url.Values) (interface{}, error) {
+	req := unet.DescribeFirewallRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	if req.FWId != nil {
+		if _, ok := s.firewalls[*req.FWId]; !ok {
+			return nil, fakeErr(54002, "Firewall [%s] not exist", *req.FWId)
+		}
+		ids = []string{*req.FWId}
+	} else if req.ResourceId != nil {
+		if id, ok := s.bindings[*req.ResourceId]; ok {
+			ids = []string{id}
+		}
+	} else {
+		ids = fakeSortedKeys(s.firewalls)
+	}
+
+	var matched []unet.FirewallDataSet
+	for _, id := range ids {
+		item := s.firewalls[id].FirewallDataSet
+		item.ResourceCount = s.countFirewallResources(id)
+		matched = append(matched, item)
+	}
+
+	limit, offset := fakeStringPageArgs(req.Limit, req.Offset)
+	start, end := fakePage(len(matched), limit, offset, 20)
+	resp := unet.DescribeFirewallResponse{DataSet: matched[start:end]}
+	if resp.DataSet == nil {
+		resp.DataSet = []unet.FirewallDataSet{}
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) countFirewallResources(fwId string) int {
+	count := 0
+	for _, id := range s.bindings {
+		if id == fwId {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *fakeUCloudAPI) updateFirewall(q url.Values) (interface{}, error) {
+	req := unet.UpdateFirewallRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	fw, err := s.getFirewall(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Rule) == 0 {
+		return nil, fakeMissingParam("Rule")
+	}
+
+	rules, err := parseFakeFirewallRules(req.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	fw.Rule = rules
+	return unet.UpdateFirewallResponse{FWId: fw.FWId}, nil
+}
+
+func (s *fakeUCloudAPI) updateFirewallAttribute(q url.Values) (interface{}, error) {
+	fw, err := s.getFirewall(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := q["Name"]; ok {
+		fw.Name = v[0]
+	}
+	if v, ok := q["Tag"]; ok {
+		fw.Tag = v[0]
+	}
+	if v, ok := q["Remark"]; ok {
+		fw.Remark = v[0]
+	}
+	return unet.UpdateFirewallAttributeResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) grantFirewall(q url.Values) (interface{}, error) {
+	fw, err := s.getFirewall(q)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := q.Get("ResourceId")
+	switch q.Get("ResourceType") {
+	case "UHost":
+		if _, ok := s.instances[resourceId]; !ok {
+			return nil, fakeErr(8039, "UHost [%s] not exist", resourceId)
+		}
+	default:
+		return nil, fakeErr(230, "Params [ResourceType] not available")
+	}
+
+	s.bindings[resourceId] = fw.FWId
+	return unet.GrantFirewallResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) deleteFirewall(q url.Values) (interface{}, error) {
+	fw, err := s.getFirewall(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.countFirewallResources(fw.FWId) > 0 {
+		return nil, fakeErr(54003, "Firewall [%s] is in use", fw.FWId)
+	}
+
+	delete(s.firewalls, fw.FWId)
+	return unet.DeleteFirewallResponse{}, nil
+}
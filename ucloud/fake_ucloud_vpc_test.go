@@ -0,0 +1,423 @@
+package ucloud
+
+import (
+	"encoding/binary"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/ucloud/ucloud-sdk-go/services/vpc"
+)
+
+type fakeVPC struct {
+	vpc.VPCInfo
+}
+
+type fakeSubnet struct {
+	vpc.VPCSubnetInfoSet
+	allocated int
+}
+
+// the default vpc and subnet is used by the instance without vpc and subnet
+const (
+	fakeDefaultVPCId    = "uvnet-fakedefault"
+	fakeDefaultSubnetId = "subnet-fakedefault"
+)
+
+func (s *fakeUCloudAPI) registerVPC() {
+	s.vpcs[fakeDefaultVPCId] = &fakeVPC{vpc.VPCInfo{
+		VPCId:       fakeDefaultVPCId,
+		Name:        "DefaultVPC",
+		Tag:         "Default",
+		Network:     []string{"10.9.0.0/16"},
+		NetworkInfo: []vpc.VPCNetworkInfo{{Network: "10.9.0.0/16", SubnetCount: 1}},
+		SubnetCount: 1,
+		CreateTime:  1500000000,
+		UpdateTime:  1500000000,
+	}}
+	s.subnets[fakeDefaultSubnetId] = &fakeSubnet{VPCSubnetInfoSet: vpc.VPCSubnetInfoSet{
+		VPCId:      fakeDefaultVPCId,
+		VPCName:    "DefaultVPC",
+		SubnetId:   fakeDefaultSubnetId,
+		SubnetName: "DefaultNetwork",
+		Name:       "DefaultNetwork",
+		Tag:        "Default",
+		SubnetType: 2,
+		Subnet:     "10.9.0.0",
+		Netmask:    "16",
+		Gateway:    "10.9.0.1",
+		CreateTime: 1500000000,
+	}}
+
+	s.handle("CreateVPC", s.createVPC)
+	s.handle("DescribeVPC", s.describeVPC)
+	s.handle("DeleteVPC", s.deleteVPC)
+	s.handle("CreateSubnet", s.createSubnet)
+	s.handle("DescribeSubnet", s.describeSubnet)
+	s.handle("UpdateSubnetAttribute", s.updateSubnetAttribute)
+	s.handle("DeleteSubnet", s.deleteSubnet)
+	s.handle("CreateVPCIntercom", s.createVPCIntercom)
+	s.handle("DescribeVPCIntercom", s.describeVPCIntercom)
+	s.handle("DeleteVPCIntercom", s.deleteVPCIntercom)
+}
+
+// resolveInstanceSubnet returns the subnet of the new instance, the default subnet is used if it is not set
+func (s *fakeUCloudAPI) resolveInstanceSubnet(vpcId, subnetId *string) (*fakeSubnet, error) {
+	if subnetId == nil {
+		if vpcId != nil && *vpcId != fakeDefaultVPCId {
+			return nil, fakeMissingParam("SubnetId")
+		}
+		return s.subnets[fakeDefaultSubnetId], nil
+	}
+
+	subnet, ok := s.subnets[*subnetId]
+	if !ok {
+		return nil, fakeErr(58101, "Subnet [%s] not exist", *subnetId)
+	}
+
+	if vpcId != nil && *vpcId != subnet.VPCId {
+		return nil, fakeErr(58102, "Subnet [%s] is not belong to VPC [%s]", *subnetId, *vpcId)
+	}
+	return subnet, nil
+}
+
+// allocPrivateIP returns the next available ip of subnet, the first two ip are reserved
+func (s *fakeUCloudAPI) allocPrivateIP(subnet *fakeSubnet) string {
+	subnet.allocated++
+	ip := net.ParseIP(subnet.Subnet).To4()
+	n := binary.BigEndian.Uint32(ip) + uint32(subnet.allocated) + 1
+	result := make(net.IP, 4)
+	binary.BigEndian.PutUint32(result, n)
+	return result.String()
+}
+
+// checkPrivateIP returns error if the specified ip is not in the subnet or it is in use
+func (s *fakeUCloudAPI) checkPrivateIP(subnet *fakeSubnet, ip string) error {
+	_, ipNet, _ := net.ParseCIDR(subnet.Subnet + "/" + subnet.Netmask)
+	if ipNet == nil || !ipNet.Contains(net.ParseIP(ip)) {
+		return fakeErr(230, "Params [PrivateIp] not available")
+	}
+
+	for _, instance := range s.instances {
+		for _, item := range instance.IPSet {
+			if item.IP == ip {
+				return fakeErr(58104, "IP [%s] is in use", ip)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *fakeUCloudAPI) isSubnetInUse(subnetId string) bool {
+	for _, instance := range s.instances {
+		for _, ip := range instance.IPSet {
+			if ip.SubnetId == subnetId {
+				return true
+			}
+		}
+	}
+	for _, lb := range s.lbs {
+		if lb.SubnetId == subnetId {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fakeUCloudAPI) getVPC(q url.Values) (*fakeVPC, error) {
+	id := q.Get("VPCId")
+	if id == "" {
+		return nil, fakeMissingParam("VPCId")
+	}
+
+	v, ok := s.vpcs[id]
+	if !ok {
+		return nil, fakeErr(58103, "VPC [%s] not exist", id)
+	}
+	return v, nil
+}
+
+func (s *fakeUCloudAPI) createVPC(q url.Values) (interface{}, error) {
+	req := vpc.CreateVPCRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	if req.Name == nil {
+		return nil, fakeMissingParam("Name")
+	}
+	if len(req.Network) == 0 {
+		return nil, fakeMissingParam("Network")
+	}
+
+	v := &fakeVPC{}
+	v.VPCId = s.newId("uvnet")
+	v.Name = *req.Name
+	v.Tag = fakeStringValue(req.Tag, "Default")
+	v.CreateTime = s.now()
+	v.UpdateTime = v.CreateTime
+	for i, network := range req.Network {
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			return nil, fakeErr(230, "Params [Network.%d] not available", i)
+		}
+		v.Network = append(v.Network, network)
+		v.NetworkInfo = append(v.NetworkInfo, vpc.VPCNetworkInfo{Network: network})
+	}
+	s.vpcs[v.VPCId] = v
+
+	return vpc.CreateVPCResponse{VPCId: v.VPCId}, nil
+}
+
+func (s *fakeUCloudAPI) describeVPC(q url.Values) (interface{}, error) {
+	req := vpc.DescribeVPCRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	resp := vpc.DescribeVPCResponse{DataSet: []vpc.VPCInfo{}}
+	for _, id := range fakeSortedKeys(s.vpcs) {
+		v := s.vpcs[id]
+		if !fakeInStrings(id, req.VPCIds) {
+			continue
+		}
+		if req.Tag != nil && *req.Tag != v.Tag {
+			continue
+		}
+
+		item := v.VPCInfo
+		item.SubnetCount = 0
+		for _, subnet := range s.subnets {
+			if subnet.VPCId == id {
+				item.SubnetCount++
+			}
+		}
+		resp.DataSet = append(resp.DataSet, item)
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) deleteVPC(q url.Values) (interface{}, error) {
+	v, err := s.getVPC(q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subnet := range s.subnets {
+		if subnet.VPCId == v.VPCId {
+			return nil, fakeErr(58104, "VPC [%s] has subnet [%s]", v.VPCId, subnet.SubnetId)
+		}
+	}
+
+	for _, peerId := range s.intercoms[v.VPCId] {
+		s.intercoms[peerId] = fakeRemoveString(s.intercoms[peerId], v.VPCId)
+	}
+	delete(s.intercoms, v.VPCId)
+	delete(s.vpcs, v.VPCId)
+	return vpc.DeleteVPCResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) createSubnet(q url.Values) (interface{}, error) {
+	req := vpc.CreateSubnetRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	v, err := s.getVPC(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Subnet == nil {
+		return nil, fakeMissingParam("Subnet")
+	}
+	mask := fakeIntValue(req.Netmask, 24)
+
+	_, ipNet, err := net.ParseCIDR(*req.Subnet + "/" + strconv.Itoa(mask))
+	if err != nil || ipNet.IP.String() != *req.Subnet {
+		return nil, fakeErr(230, "Params [Subnet] not available")
+	}
+
+	inVPC := false
+	for _, network := range v.Network {
+		_, vpcNet, err := net.ParseCIDR(network)
+		if err != nil {
+			continue
+		}
+		if vpcMask, _ := vpcNet.Mask.Size(); vpcNet.Contains(ipNet.IP) && vpcMask <= mask {
+			inVPC = true
+		}
+	}
+	if !inVPC {
+		return nil, fakeErr(58105, "Subnet [%s/%d] is not in the network of VPC [%s]", *req.Subnet, mask, v.VPCId)
+	}
+
+	for _, subnet := range s.subnets {
+		if subnet.VPCId != v.VPCId {
+			continue
+		}
+		_, other, _ := net.ParseCIDR(subnet.Subnet + "/" + subnet.Netmask)
+		if other.Contains(ipNet.IP) || ipNet.Contains(other.IP) {
+			return nil, fakeErr(58106, "Subnet [%s/%d] is overlapped with subnet [%s]", *req.Subnet, mask, subnet.SubnetId)
+		}
+	}
+
+	gateway := make(net.IP, 4)
+	binary.BigEndian.PutUint32(gateway, binary.BigEndian.Uint32(ipNet.IP.To4())+1)
+
+	subnet := &fakeSubnet{}
+	subnet.SubnetId = s.newId("subnet")
+	subnet.VPCId = v.VPCId
+	subnet.VPCName = v.Name
+	subnet.SubnetName = fakeStringValue(req.SubnetName, "Subnet")
+	subnet.Name = subnet.SubnetName
+	subnet.Tag = fakeStringValue(req.Tag, "Default")
+	subnet.Remark = fakeStringValue(req.Remark, "")
+	subnet.Subnet = *req.Subnet
+	subnet.Netmask = strconv.Itoa(mask)
+	subnet.Gateway = gateway.String()
+	subnet.SubnetType = 2
+	subnet.CreateTime = s.now()
+	s.subnets[subnet.SubnetId] = subnet
+
+	return vpc.CreateSubnetResponse{SubnetId: subnet.SubnetId}, nil
+}
+
+func (s *fakeUCloudAPI) describeSubnet(q url.Values) (interface{}, error) {
+	req := vpc.DescribeSubnetRequest{}
+	if err := fakeDecodeRequest(q, &req); err != nil {
+		return nil, err
+	}
+
+	var matched []vpc.VPCSubnetInfoSet
+	for _, id := range fakeSortedKeys(s.subnets) {
+		subnet := s.subnets[id]
+		if !fakeInStrings(id, req.SubnetIds) {
+			continue
+		}
+		if req.SubnetId != nil && *req.SubnetId != id {
+			continue
+		}
+		if req.VPCId != nil && *req.VPCId != subnet.VPCId {
+			continue
+		}
+		if req.Tag != nil && *req.Tag != subnet.Tag {
+			continue
+		}
+		matched = append(matched, subnet.VPCSubnetInfoSet)
+	}
+
+	start, end := fakePage(len(matched), req.Limit, req.Offset, 20)
+	resp := vpc.DescribeSubnetResponse{TotalCount: len(matched), DataSet: matched[start:end]}
+	if resp.DataSet == nil {
+		resp.DataSet = []vpc.VPCSubnetInfoSet{}
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) getSubnet(q url.Values) (*fakeSubnet, error) {
+	id := q.Get("SubnetId")
+	if id == "" {
+		return nil, fakeMissingParam("SubnetId")
+	}
+
+	subnet, ok := s.subnets[id]
+	if !ok {
+		return nil, fakeErr(58101, "Subnet [%s] not exist", id)
+	}
+	return subnet, nil
+}
+
+func (s *fakeUCloudAPI) updateSubnetAttribute(q url.Values) (interface{}, error) {
+	subnet, err := s.getSubnet(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := q["Name"]; ok {
+		subnet.SubnetName = v[0]
+		subnet.Name = v[0]
+	}
+	if v, ok := q["Tag"]; ok {
+		subnet.Tag = v[0]
+	}
+	return vpc.UpdateSubnetAttributeResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) deleteSubnet(q url.Values) (interface{}, error) {
+	subnet, err := s.getSubnet(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.isSubnetInUse(subnet.SubnetId) {
+		return nil, fakeErr(58107, "Subnet [%s] has resources in use", subnet.SubnetId)
+	}
+
+	delete(s.subnets, subnet.SubnetId)
+	return vpc.DeleteSubnetResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) createVPCIntercom(q url.Values) (interface{}, error) {
+	v, err := s.getVPC(q)
+	if err != nil {
+		return nil, err
+	}
+
+	dstId := q.Get("DstVPCId")
+	if _, ok := s.vpcs[dstId]; !ok {
+		return nil, fakeErr(58103, "VPC [%s] not exist", dstId)
+	}
+
+	for _, id := range s.intercoms[v.VPCId] {
+		if id == dstId {
+			return nil, fakeErr(58108, "VPC [%s] has been connected with VPC [%s]", v.VPCId, dstId)
+		}
+	}
+
+	s.intercoms[v.VPCId] = append(s.intercoms[v.VPCId], dstId)
+	s.intercoms[dstId] = append(s.intercoms[dstId], v.VPCId)
+	return vpc.CreateVPCIntercomResponse{}, nil
+}
+
+func (s *fakeUCloudAPI) describeVPCIntercom(q url.Values) (interface{}, error) {
+	v, err := s.getVPC(q)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := vpc.DescribeVPCIntercomResponse{DataSet: []vpc.VPCIntercomInfo{}}
+	for _, id := range s.intercoms[v.VPCId] {
+		peer := s.vpcs[id]
+		resp.DataSet = append(resp.DataSet, vpc.VPCIntercomInfo{
+			ProjectId: fakeQueryValue(q, "DstProjectId", fakeProjectId),
+			DstRegion: fakeQueryValue(q, "DstRegion", fakeRegion),
+			Network:   peer.Network,
+			Name:      peer.Name,
+			VPCId:     peer.VPCId,
+			Tag:       peer.Tag,
+		})
+	}
+	return resp, nil
+}
+
+func (s *fakeUCloudAPI) deleteVPCIntercom(q url.Values) (interface{}, error) {
+	v, err := s.getVPC(q)
+	if err != nil {
+		return nil, err
+	}
+
+	dstId := q.Get("DstVPCId")
+	s.intercoms[v.VPCId] = fakeRemoveString(s.intercoms[v.VPCId], dstId)
+	s.intercoms[dstId] = fakeRemoveString(s.intercoms[dstId], v.VPCId)
+	return vpc.DeleteVPCIntercomResponse{}, nil
+}
+
+func fakeRemoveString(list []string, s string) []string {
+	var result []string
+	for _, v := range list {
+		if v != s {
+			result = append(result, v)
+		}
+	}
+	return result
+}
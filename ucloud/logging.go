@@ -0,0 +1,217 @@
+package ucloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/hashicorp/terraform/helper/logging"
+	sdklog "github.com/ucloud/ucloud-sdk-go/ucloud/log"
+)
+
+// logRedacted is the placeholder of secret in log
+const logRedacted = "******"
+
+// logSensitiveKeyPattern is used to match the name of param or field which value is secret,
+// such as Password, AdminPassword and PrivateKey
+var logSensitiveKeyPattern = regexp.MustCompile(`(?i)(password|private_?key|secret)`)
+
+// logSensitiveTextPatterns is used to redact the secrets in plain text, such as the url with query string
+var logSensitiveTextPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(\w*(?:password|private_?key|secret)"?\s*[=:]\s*"?)[^&"\s,}]+`),
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+}
+
+func isLogSensitiveKey(key string) bool {
+	return logSensitiveKeyPattern.MatchString(key)
+}
+
+// redactLogQuery will redact the secret params of query and return it as a sorted query string
+func redactLogQuery(q url.Values) string {
+	var keys []string
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var items []string
+	for _, k := range keys {
+		for _, v := range q[k] {
+			if isLogSensitiveKey(k) {
+				v = logRedacted
+			}
+			items = append(items, k+"="+url.QueryEscape(v))
+		}
+	}
+	return strings.Join(items, "&")
+}
+
+// redactLogText will redact the secrets in plain text
+func redactLogText(s string) string {
+	s = logSensitiveTextPatterns[0].ReplaceAllString(s, "${1}"+logRedacted)
+	return logSensitiveTextPatterns[1].ReplaceAllString(s, logRedacted)
+}
+
+// redactLogBody will redact the secret fields of json body, the body which is not json is redacted as plain text
+func redactLogBody(body []byte) string {
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return redactLogText(string(body))
+	}
+
+	redacted, err := json.Marshal(redactLogValue(data))
+	if err != nil {
+		return redactLogText(string(body))
+	}
+	return string(redacted)
+}
+
+func redactLogValue(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if _, ok := item.(string); ok && isLogSensitiveKey(key) {
+				v[key] = logRedacted
+				continue
+			}
+			v[key] = redactLogValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactLogValue(item)
+		}
+	case string:
+		return redactLogText(v)
+	}
+	return data
+}
+
+// loggingTransport is used to log every api call with action, region, project, latency, RetCode and request id,
+// the request and response are also logged with secrets redacted if the log level is TRACE.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	q := req.URL.Query()
+	action := q.Get("Action")
+	fields := fmt.Sprintf("action=%s region=%s project=%s", action, q.Get("Region"), q.Get("ProjectId"))
+
+	trace := logging.LogLevel() == "TRACE"
+	if trace {
+		log.Printf("[TRACE] UCloud API request: %s %s://%s%s?%s", req.Method, req.URL.Scheme, req.URL.Host, req.URL.Path, redactLogQuery(q))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	if err != nil {
+		log.Printf("[DEBUG] UCloud API call: %s latency=%s error=%q", fields, latency, redactLogText(err.Error()))
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		log.Printf("[DEBUG] UCloud API call: %s latency=%s status=%v error=%q", fields, latency, resp.StatusCode, err)
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var data struct {
+		RetCode     int
+		Message     string
+		RequestUUID string
+	}
+	json.Unmarshal(body, &data)
+
+	requestId := data.RequestUUID
+	if requestId == "" {
+		requestId = resp.Header.Get("X-UCloud-Request-Uuid")
+	}
+
+	if data.RetCode != 0 {
+		log.Printf("[DEBUG] UCloud API call: %s latency=%s status=%v retcode=%v request_id=%s message=%q",
+			fields, latency, resp.StatusCode, data.RetCode, requestId, redactLogText(data.Message))
+	} else {
+		log.Printf("[DEBUG] UCloud API call: %s latency=%s status=%v retcode=%v request_id=%s",
+			fields, latency, resp.StatusCode, data.RetCode, requestId)
+	}
+
+	if trace {
+		log.Printf("[TRACE] UCloud API response of %s: %s", action, redactLogBody(body))
+	}
+
+	return resp, nil
+}
+
+// sdkLogLevel will return the log level of sdk by the log level of terraform,
+// the debug log of sdk is the raw request and response, so that it is only enabled by TRACE.
+func sdkLogLevel() sdklog.Level {
+	switch logging.LogLevel() {
+	case "TRACE":
+		return sdklog.DebugLevel
+	case "DEBUG":
+		return sdklog.InfoLevel
+	case "INFO", "WARN":
+		return sdklog.WarnLevel
+	}
+	return sdklog.ErrorLevel
+}
+
+// sdkLogFormatter will format the log entry of sdk with the level prefix of terraform,
+// the secrets in the message and fields are redacted.
+type sdkLogFormatter struct{}
+
+func (f *sdkLogFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	level := "ERROR"
+	switch entry.Level {
+	case logrus.DebugLevel:
+		level = "TRACE"
+	case logrus.InfoLevel:
+		level = "DEBUG"
+	case logrus.WarnLevel:
+		level = "WARN"
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "[%s] ucloud-sdk: %s", level, redactLogText(entry.Message))
+
+	var keys []string
+	for k := range entry.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&buf, " %s=%s", k, redactLogText(fmt.Sprint(entry.Data[k])))
+	}
+	return buf.Bytes(), nil
+}
+
+// logWriter will write the log of sdk into the standard logger which is captured by terraform
+type logWriter struct{}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	log.Print(string(p))
+	return len(p), nil
+}
+
+// initSDKLogger will route the log of sdk to terraform, it must be called after the sdk client is created,
+// because the sdk will set the output of its logger to stdout when the client is created.
+func initSDKLogger() {
+	sdklog.SetLevel(sdkLogLevel())
+	sdklog.SetOutput(&logWriter{})
+	sdklog.SetFormatter(&sdkLogFormatter{})
+}
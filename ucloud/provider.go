@@ -1,6 +1,8 @@
 package ucloud
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -8,36 +10,101 @@ import (
 
 // Provider returns a terraform.ResourceProvider.
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"public_key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("UCLOUD_PUBLIC_KEY", nil),
 				Description: descriptions["public_key"],
 			},
 
 			"private_key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("UCLOUD_PRIVATE_KEY", nil),
 				Description: descriptions["private_key"],
 			},
 
 			"region": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("UCLOUD_REGION", nil),
 				Description: descriptions["region"],
 			},
 
 			"project_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("UCLOUD_PROJECT_ID", nil),
 				Description: descriptions["project_id"],
 			},
 
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("UCLOUD_PROFILE", ""),
+				Description: descriptions["profile"],
+			},
+
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("UCLOUD_SHARED_CREDENTIAL_FILE", DefaultSharedCredentialsFile),
+				Description: descriptions["shared_credentials_file"],
+			},
+
+			"shared_config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("UCLOUD_SHARED_CONFIG_FILE", DefaultSharedConfigFile),
+				Description: descriptions["shared_config_file"],
+			},
+
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultRequestTimeout,
+				Description:  descriptions["request_timeout"],
+				ValidateFunc: validateIntegerInRange(1, 3600),
+			},
+
+			"user_agent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("UCLOUD_USER_AGENT", ""),
+				Description: descriptions["user_agent"],
+			},
+
+			"proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("UCLOUD_PROXY", ""),
+				Description:  descriptions["proxy"],
+				ValidateFunc: validateProxyURL,
+			},
+
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("UCLOUD_CA_BUNDLE", ""),
+				Description: descriptions["ca_bundle"],
+			},
+
+			"skip_tls_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["skip_tls_verify"],
+			},
+
+			"credential_process": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("UCLOUD_CREDENTIAL_PROCESS", ""),
+				Description: descriptions["credential_process"],
+			},
+
 			"max_retries": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -45,12 +112,46 @@ func Provider() terraform.ResourceProvider {
 				Description: descriptions["max_retries"],
 			},
 
+			"rate_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultRateLimit,
+				Description:  descriptions["rate_limit"],
+				ValidateFunc: validateIntegerInRange(0, 1000),
+			},
+
+			"rate_burst": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultRateBurst,
+				Description:  descriptions["rate_burst"],
+				ValidateFunc: validateIntegerInRange(1, 1000),
+			},
+
+			"max_throttle_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultMaxThrottleRetries,
+				Description:  descriptions["max_throttle_retries"],
+				ValidateFunc: validateIntegerInRange(0, 100),
+			},
+
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     DefaultInSecure,
 				Description: descriptions["insecure"],
 			},
+
+			"base_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("UCLOUD_BASE_URL", ""),
+				Description:  descriptions["base_url"],
+				ValidateFunc: validateEndpointURL,
+			},
+
+			"endpoints": endpointsSchema(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -62,9 +163,13 @@ func Provider() terraform.ResourceProvider {
 			"ucloud_instance_types":      dataSourceUCloudInstanceTypes(),
 			"ucloud_db_parameter_groups": dataSourceUCloudDBParameterGroups(),
 			"ucloud_db_backups":          dataSourceUCloudDBBackups(),
+			"ucloud_disks":               dataSourceUCloudDisks(),
+			"ucloud_disk_snapshots":      dataSourceUCloudDiskSnapshots(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"ucloud_instance":               resourceUCloudInstance(),
+			"ucloud_image":                  resourceUCloudImage(),
+			"ucloud_key_pair":               resourceUCloudKeyPair(),
 			"ucloud_eip":                    resourceUCloudEIP(),
 			"ucloud_eip_association":        resourceUCloudEIPAssociation(),
 			"ucloud_vpc":                    resourceUCloudVPC(),
@@ -76,13 +181,23 @@ func Provider() terraform.ResourceProvider {
 			"ucloud_lb_rule":                resourceUCloudLBRule(),
 			"ucloud_disk":                   resourceUCloudDisk(),
 			"ucloud_disk_attachment":        resourceUCloudDiskAttachment(),
+			"ucloud_disk_snapshot":          resourceUCloudDiskSnapshot(),
 			"ucloud_security_group":         resourceUCloudSecurityGroup(),
 			"ucloud_db_instance":            resourceUCloudDBInstance(),
 			"ucloud_db_parameter_group":     resourceUCloudDBParameterGroup(),
 			"ucloud_db_slave":               resourceUCloudDBSlave(),
 		},
-		ConfigureFunc: providerConfigure,
 	}
+
+	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		// the transport of previous configuration is released, so that it will not be leaked after reconfigure
+		if client, ok := p.Meta().(*UCloudClient); ok {
+			client.Close()
+		}
+		return providerConfigure(d)
+	}
+
+	return p
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -90,18 +205,92 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		PublicKey:  d.Get("public_key").(string),
 		PrivateKey: d.Get("private_key").(string),
 		Region:     d.Get("region").(string),
+		ProjectId:  d.Get("project_id").(string),
 		MaxRetries: d.Get("max_retries").(int),
 		Insecure:   d.Get("insecure").(bool),
+		BaseUrl:    d.Get("base_url").(string),
+		Endpoints:  expandEndpoints(d.Get("endpoints").([]interface{})),
+
+		RequestTimeout: d.Get("request_timeout").(int),
+		UserAgent:      d.Get("user_agent").(string),
+		Proxy:          d.Get("proxy").(string),
+		CABundle:       d.Get("ca_bundle").(string),
+		SkipTLSVerify:  d.Get("skip_tls_verify").(bool),
+
+		CredentialProcess: d.Get("credential_process").(string),
+
+		RateLimit:          d.Get("rate_limit").(int),
+		RateBurst:          d.Get("rate_burst").(int),
+		MaxThrottleRetries: d.Get("max_throttle_retries").(int),
+	}
+
+	// the static credentials and environment variables take precedence over the shared credentials file
+	credentialsFile := d.Get("shared_credentials_file").(string)
+	configFile := d.Get("shared_config_file").(string)
+	profileName := d.Get("profile").(string)
+	if shouldLoadProfile(config.PublicKey, config.PrivateKey, credentialsFile, configFile, profileName) {
+		profile, err := loadProfile(credentialsFile, configFile, profileName)
+		if err != nil {
+			return nil, err
+		}
+
+		// the credential process takes precedence over the shared credentials file
+		if config.PublicKey == "" && config.PrivateKey == "" && config.CredentialProcess == "" {
+			config.PublicKey = profile.PublicKey
+			config.PrivateKey = profile.PrivateKey
+		}
+		if config.Region == "" {
+			config.Region = profile.Region
+		}
+		if config.ProjectId == "" {
+			config.ProjectId = profile.ProjectId
+		}
+		if config.BaseUrl == "" {
+			config.BaseUrl = profile.BaseUrl
+		}
 	}
 
-	if projectId, ok := d.GetOk("project_id"); ok && projectId.(string) != "" {
-		config.ProjectId = projectId.(string)
+	if err := checkRequiredConfig(&config); err != nil {
+		return nil, err
 	}
 
 	client, err := config.Client()
 	return client, err
 }
 
+// checkRequiredConfig will check the required arguments which may be set by static credentials,
+// environment variables or shared credentials file.
+func checkRequiredConfig(config *Config) error {
+	type requiredItem struct {
+		name  string
+		env   string
+		value string
+	}
+
+	var required []requiredItem
+
+	// the keys will be retrieved by credential process if they are not set
+	if config.PublicKey != "" || config.PrivateKey != "" || config.CredentialProcess == "" {
+		required = append(required,
+			requiredItem{"public_key", "UCLOUD_PUBLIC_KEY", config.PublicKey},
+			requiredItem{"private_key", "UCLOUD_PRIVATE_KEY", config.PrivateKey},
+		)
+	}
+
+	required = append(required,
+		requiredItem{"region", "UCLOUD_REGION", config.Region},
+		requiredItem{"project_id", "UCLOUD_PROJECT_ID", config.ProjectId},
+	)
+
+	for _, item := range required {
+		if item.value == "" {
+			return fmt.Errorf("%q must be set, it can be set in provider block, by %s environment variable or in shared credentials file", item.name, item.env)
+		}
+	}
+
+	return nil
+}
+
 var ucloudMutexKV = mutexkv.NewMutexKV()
 
 var descriptions map[string]string
@@ -113,6 +302,62 @@ func init() {
 		"region":      "...",
 		"project_id":  "...",
 		"max_retries": "...",
+		"profile":     "...",
 		"insecure":    "...",
+		"base_url":    "...",
+		"endpoints":   "...",
+
+		"shared_credentials_file": "...",
+		"shared_config_file":      "...",
+		"credential_process":      "...",
+		"request_timeout":         "...",
+		"user_agent":              "...",
+		"proxy":                   "...",
+		"ca_bundle":               "...",
+		"skip_tls_verify":         "...",
+		"rate_limit":              "...",
+		"rate_burst":              "...",
+		"max_throttle_retries":    "...",
+	}
+
+	for _, service := range endpointServices {
+		descriptions[service+"_endpoint"] = fmt.Sprintf("Use this to override the default endpoint url of %s api", service)
+	}
+}
+
+func endpointsSchema() *schema.Schema {
+	endpointSchema := make(map[string]*schema.Schema)
+	for _, service := range endpointServices {
+		endpointSchema[service] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "",
+			Description:  descriptions[service+"_endpoint"],
+			ValidateFunc: validateEndpointURL,
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: descriptions["endpoints"],
+		Elem: &schema.Resource{
+			Schema: endpointSchema,
+		},
+	}
+}
+
+func expandEndpoints(endpoints []interface{}) map[string]string {
+	result := make(map[string]string)
+	if len(endpoints) == 0 || endpoints[0] == nil {
+		return result
+	}
+
+	for k, v := range endpoints[0].(map[string]interface{}) {
+		if url := v.(string); url != "" {
+			result[k] = url
+		}
 	}
+	return result
 }
@@ -3,9 +3,11 @@ package ucloud
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -16,6 +18,12 @@ var testAccProvider *schema.Provider
 
 func init() {
 	testAccProvider = Provider().(*schema.Provider)
+	if mode := cassetteMode(); mode != "" {
+		// the cassette is in front of the transport router of provider,
+		// so that it can replay the requests without any provider transport
+		testAccCassetteTransport = newCassetteTransport(mode, cassetteDir(), getTransportRouter())
+		http.DefaultTransport = testAccCassetteTransport
+	}
 	testAccProviders = map[string]terraform.ResourceProvider{
 		"ucloud": testAccProvider,
 	}
@@ -27,11 +35,59 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProvider_reconfigure(t *testing.T) {
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"public_key":  "pub",
+		"private_key": "pri",
+		"region":      "cn-bj2",
+		"project_id":  "org-test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := getTransportRouter()
+	p := Provider().(*schema.Provider)
+	if err := p.Configure(terraform.NewResourceConfig(raw)); err != nil {
+		t.Fatal(err)
+	}
+	first := p.Meta().(*UCloudClient).routeToken
+
+	if err := p.Configure(terraform.NewResourceConfig(raw)); err != nil {
+		t.Fatal(err)
+	}
+	second := p.Meta().(*UCloudClient).routeToken
+	defer p.Meta().(*UCloudClient).Close()
+
+	router.mu.RLock()
+	defer router.mu.RUnlock()
+	if _, ok := router.routes[first]; ok {
+		t.Errorf("the route %s of previous configuration should be removed", first)
+	}
+	if _, ok := router.routes[second]; !ok {
+		t.Errorf("the route %s of current configuration should be registered", second)
+	}
+}
+
 func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
 func testAccPreCheck(t *testing.T) {
+	if isFakeUCloudAPIEnabled() || isCassetteReplaying() {
+		// never send the real credential to the fake api server
+		os.Setenv("UCLOUD_PUBLIC_KEY", fakePublicKey)
+		os.Setenv("UCLOUD_PRIVATE_KEY", fakePrivateKey)
+		os.Setenv("UCLOUD_PROJECT_ID", fakeProjectId)
+		os.Setenv("UCLOUD_REGION", fakeRegion)
+	}
+	if isFakeUCloudAPIEnabled() {
+		os.Setenv("UCLOUD_BASE_URL", testAccFakeUCloudAPI().URL())
+	}
+	if err := testAccUseCassette(t.Name()); err != nil {
+		t.Fatal(err)
+	}
+
 	if v := os.Getenv("UCLOUD_PUBLIC_KEY"); v == "" {
 		t.Fatal("UCLOUD_PUBLIC_KEY must be set for acceptance tests")
 	}
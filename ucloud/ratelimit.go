@@ -0,0 +1,203 @@
+package ucloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// busyRetCodes is the RetCode of api which means the resource is busy in another operation,
+// the other RetCode of resource in use such as the parameter group used by db instance will not be retried.
+var busyRetCodes = map[int]string{
+	5009:  "udb instance is busy",
+	17060: "udisk is busy",
+}
+
+const (
+	// retryMinDelay is the delay of the first retry after the request is throttled
+	retryMinDelay = 500 * time.Millisecond
+
+	// retryMaxDelay is the max delay between retries
+	retryMaxDelay = 20 * time.Second
+)
+
+// isRetryableRetCode will check the request with the RetCode can be sent again later
+func isRetryableRetCode(code int) bool {
+	if getRetCodeClass(code) == Throttled {
+		return true
+	}
+	_, ok := busyRetCodes[code]
+	return ok
+}
+
+// tokenBucket is a rate limiter which allows the events up to rate per second with bursts of at most burst events
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve will take a token from bucket and return the duration to wait for it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel will give back the token which is reserved but not used
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+}
+
+// Wait will block until a token is available or the context is done
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// rateLimitTransport is used to limit the rate of requests sent by all of the product connections of provider,
+// and send the request again with jittered backoff if it is throttled by server or the resource is busy.
+// The network error is retried by sdk, so that it is returned directly.
+type rateLimitTransport struct {
+	limiter    *tokenBucket
+	maxRetries int
+	next       http.RoundTripper
+}
+
+func newRateLimitTransport(rateLimit, rateBurst, maxRetries int, next http.RoundTripper) *rateLimitTransport {
+	t := &rateLimitTransport{maxRetries: maxRetries, next: next}
+	if rateLimit > 0 {
+		t.limiter = newTokenBucket(rateLimit, rateBurst)
+	}
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	action := req.URL.Query().Get("Action")
+
+	for attempt := 0; ; attempt++ {
+		if t.limiter != nil {
+			if err := t.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		retryable, code, err := isRetryableResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		if !retryable || attempt >= t.maxRetries {
+			return resp, nil
+		}
+
+		// the request will not be sent again if the backoff is exceeded the deadline of request,
+		// the last response is returned and the error will be raised by sdk
+		delay := retryBackoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+		log.Printf("[DEBUG] %s is retried after %s, got RetCode %v, attempt %v", action, delay, code, attempt+1)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// isRetryableResponse will check the response is throttled or busy by http status code and RetCode,
+// the body of response is restored so that it can be read again by sdk.
+func isRetryableResponse(resp *http.Response) (bool, int, error) {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		return true, resp.StatusCode, nil
+	}
+
+	if resp.StatusCode != http.StatusOK || resp.Body == nil {
+		return false, 0, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return false, 0, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var data struct {
+		RetCode int
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return false, 0, nil
+	}
+	return isRetryableRetCode(data.RetCode), data.RetCode, nil
+}
+
+// retryBackoff will return the exponential backoff of attempt with random jitter,
+// so that the concurrent requests throttled at the same time will not be sent again at the same time.
+func retryBackoff(attempt int) time.Duration {
+	delay := retryMaxDelay
+	if attempt < 16 {
+		if d := retryMinDelay << uint(attempt); d < retryMaxDelay {
+			delay = d
+		}
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
@@ -0,0 +1,92 @@
+package ucloud
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_tokenBucket(t *testing.T) {
+	bucket := newTokenBucket(20, 2)
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := bucket.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the first 2 requests are allowed by burst, and the others wait for 50ms each
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least %v", elapsed, 150*time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	bucket = newTokenBucket(1, 1)
+	bucket.Wait(ctx)
+	if err := bucket.Wait(ctx); err == nil {
+		t.Errorf("Wait should return error if the context is done")
+	}
+}
+
+func Test_rateLimitTransport(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first 2 requests are throttled
+		if atomic.AddInt32(&count, 1) <= 2 {
+			fmt.Fprint(w, `{"RetCode":152,"Message":"api frequency limit exceeded"}`)
+			return
+		}
+		fmt.Fprint(w, `{"RetCode":0,"Action":"DescribeEIPResponse"}`)
+	}))
+	defer server.Close()
+
+	for _, tt := range []struct {
+		name       string
+		maxRetries int
+		want       string
+		wantCount  int32
+	}{
+		{"retried", 3, `"RetCode":0`, 3},
+		{"exhausted", 1, `"RetCode":152`, 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&count, 0)
+			transport := newRateLimitTransport(DefaultRateLimit, DefaultRateBurst, tt.maxRetries, http.DefaultTransport)
+
+			req, _ := http.NewRequest("GET", server.URL+"/?Action=DescribeEIP", nil)
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(body), tt.want) {
+				t.Errorf("body = %s, want %s", body, tt.want)
+			}
+			if got := atomic.LoadInt32(&count); got != tt.wantCount {
+				t.Errorf("count = %v, want %v", got, tt.wantCount)
+			}
+		})
+	}
+}
+
+func Test_retryBackoff(t *testing.T) {
+	for attempt := 0; attempt < 20; attempt++ {
+		delay := retryBackoff(attempt)
+		if delay < retryMinDelay/2 || delay > retryMaxDelay {
+			t.Errorf("retryBackoff(%v) = %v, want between %v and %v", attempt, delay, retryMinDelay/2, retryMaxDelay)
+		}
+	}
+}
@@ -21,6 +21,12 @@ func resourceUCloudDBInstance() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"availability_zone": &schema.Schema{
 				Type:     schema.TypeString,
@@ -149,6 +155,17 @@ func resourceUCloudDBInstance() *schema.Resource {
 				Computed:     true,
 			},
 
+			"running": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"reboot_trigger": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"status": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -279,7 +296,7 @@ func resourceUCloudDBInstanceCreate(d *schema.ResourceData, meta interface{}) er
 	d.SetId(resp.DBId)
 
 	// after create db, we need to wait it initialized
-	stateConf := client.dbWaitForState(d.Id(), "Running")
+	stateConf := client.dbWaitForState(d.Id(), []string{"Running"}, d.Timeout(schema.TimeoutCreate))
 
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf("wait for db initialize failed in create db %s, %s", d.Id(), err)
@@ -294,6 +311,31 @@ func resourceUCloudDBInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 
 	d.Partial(true)
 
+	// the db instance must be running to update the other attributes, it will be stopped at last if it is not desired running
+	if !d.IsNewResource() && (d.Get("running").(bool) || dbInstanceAttributesChanged(d)) {
+		if err := dbPowerStateConverge(client, d.Id(), d.Get("availability_zone").(string), true, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error in update db instance %s, %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("reboot_trigger") && !d.IsNewResource() && d.Get("running").(bool) {
+		d.SetPartial("reboot_trigger")
+		req := conn.NewRestartUDBInstanceRequest()
+		req.DBId = ucloud.String(d.Id())
+		req.Zone = ucloud.String(d.Get("availability_zone").(string))
+
+		if _, err := conn.RestartUDBInstance(req); err != nil {
+			return fmt.Errorf("do %s failed in update db instance %s, %s", "RestartUDBInstance", d.Id(), err)
+		}
+
+		// after restart db instance, we need to wait it running
+		stateConf := client.dbWaitForState(d.Id(), []string{"Running"}, d.Timeout(schema.TimeoutUpdate))
+
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("wait for restart db instance failed in update db instance %s, %s", d.Id(), err)
+		}
+	}
+
 	if d.HasChange("name") && !d.IsNewResource() {
 		d.SetPartial("name")
 		req := conn.NewModifyUDBInstanceNameRequest()
@@ -305,7 +347,7 @@ func resourceUCloudDBInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 
 		// after update db name, we need to wait it completed
-		stateConf := client.dbWaitForState(d.Id(), "Running")
+		stateConf := client.dbWaitForState(d.Id(), []string{"Running"}, d.Timeout(schema.TimeoutUpdate))
 
 		if _, err := stateConf.WaitForState(); err != nil {
 			return fmt.Errorf("wait for update db name failed in update db %s, %s", d.Id(), err)
@@ -323,7 +365,7 @@ func resourceUCloudDBInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 
 		// after update db password, we need to wait it completed
-		stateConf := client.dbWaitForState(d.Id(), "Running")
+		stateConf := client.dbWaitForState(d.Id(), []string{"Running"}, d.Timeout(schema.TimeoutUpdate))
 
 		if _, err := stateConf.WaitForState(); err != nil {
 			return fmt.Errorf("wait for update db password failed in update db %s, %s", d.Id(), err)
@@ -387,7 +429,7 @@ func resourceUCloudDBInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 				}
 
 				// after stop db instance, we need to wait it stopped
-				stateConf := client.dbWaitForState(d.Id(), "Shutoff")
+				stateConf := client.dbWaitForState(d.Id(), []string{"Shutoff"}, d.Timeout(schema.TimeoutUpdate))
 
 				if _, err := stateConf.WaitForState(); err != nil {
 					return fmt.Errorf("wait for stop db instance failed in update db instance %s, %s", d.Id(), err)
@@ -399,7 +441,7 @@ func resourceUCloudDBInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 			}
 
 			// after resize db instance, we need to wait it completed
-			stateConf := client.dbWaitForState(d.Id(), "Shutoff")
+			stateConf := client.dbWaitForState(d.Id(), []string{"Shutoff"}, d.Timeout(schema.TimeoutUpdate))
 
 			if _, err := stateConf.WaitForState(); err != nil {
 				return fmt.Errorf("wait for resize db instance failed in update db %s, %s", d.Id(), err)
@@ -417,7 +459,7 @@ func resourceUCloudDBInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 			}
 
 			// after db instance update, we need to wait it started
-			stateConf = client.dbWaitForState(d.Id(), "Running")
+			stateConf = client.dbWaitForState(d.Id(), []string{"Running"}, d.Timeout(schema.TimeoutUpdate))
 
 			if _, err := stateConf.WaitForState(); err != nil {
 				return fmt.Errorf("wait for start db instance failed in update db instance %s, %s", d.Id(), err)
@@ -429,7 +471,7 @@ func resourceUCloudDBInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 
 		// after resize db instance, we need to wait it completed
-		stateConf := client.dbWaitForState(d.Id(), "Running")
+		stateConf := client.dbWaitForState(d.Id(), []string{"Running"}, d.Timeout(schema.TimeoutUpdate))
 
 		if _, err := stateConf.WaitForState(); err != nil {
 			return fmt.Errorf("wait for resize db instance failed in update db %s, %s", d.Id(), err)
@@ -458,7 +500,7 @@ func resourceUCloudDBInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 
 		// after update db backup strategy, we need to wait it completed
-		stateConf := client.dbWaitForState(d.Id(), "Running")
+		stateConf := client.dbWaitForState(d.Id(), []string{"Running"}, d.Timeout(schema.TimeoutUpdate))
 
 		if _, err := stateConf.WaitForState(); err != nil {
 			return fmt.Errorf("wait for update db backup strategy failed in update db %s, %s", d.Id(), err)
@@ -476,6 +518,12 @@ func resourceUCloudDBInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 
+	if !d.Get("running").(bool) {
+		if err := dbPowerStateConverge(client, d.Id(), d.Get("availability_zone").(string), false, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error in update db instance %s, %s", d.Id(), err)
+		}
+	}
+
 	d.Partial(false)
 
 	return resourceUCloudDBInstanceRead(d, meta)
@@ -490,7 +538,7 @@ func resourceUCloudDBInstanceRead(d *schema.ResourceData, meta interface{}) erro
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read db %s, %s", "DescribeUDBInstance", d.Id(), err)
+		return newActionError("DescribeUDBInstance", "db instance", d.Id(), err)
 	}
 
 	arr := strings.Split(db.DBTypeId, "-")
@@ -500,6 +548,7 @@ func resourceUCloudDBInstanceRead(d *schema.ResourceData, meta interface{}) erro
 	d.Set("parameter_group_id", strconv.Itoa(db.ParamGroupId))
 	d.Set("port", db.Port)
 	d.Set("status", db.State)
+	d.Set("running", db.State != "Shutoff")
 	d.Set("instance_charge_type", db.ChargeType)
 	d.Set("instance_storage", db.DiskSpace)
 	d.Set("standby_zone", db.BackupZone)
@@ -533,7 +582,7 @@ func resourceUCloudDBInstanceDelete(d *schema.ResourceData, meta interface{}) er
 	stopReq := conn.NewStopUDBInstanceRequest()
 	stopReq.DBId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		db, err := client.describeDBInstanceById(d.Id())
 		if err != nil {
 			if isNotFoundError(err) {
@@ -544,11 +593,11 @@ func resourceUCloudDBInstanceDelete(d *schema.ResourceData, meta interface{}) er
 
 		if db.State != "Shutoff" {
 			if _, err := conn.StopUDBInstance(stopReq); err != nil {
-				return resource.RetryableError(fmt.Errorf("do %s failed in delete db instance %s, %s", "StopUDBInstance", d.Id(), err))
+				return resource.RetryableError(newActionError("StopUDBInstance", "db instance", d.Id(), err))
 			}
 
 			// after instance stop, we need to wait it stoped
-			stateConf := client.dbWaitForState(d.Id(), "Shutoff")
+			stateConf := client.dbWaitForState(d.Id(), []string{"Shutoff"}, d.Timeout(schema.TimeoutDelete))
 
 			if _, err := stateConf.WaitForState(); err != nil {
 				return resource.RetryableError(fmt.Errorf("wait for db instance stop failed in delete db instance %s, %s", d.Id(), err))
@@ -556,16 +605,65 @@ func resourceUCloudDBInstanceDelete(d *schema.ResourceData, meta interface{}) er
 		}
 
 		if _, err := conn.DeleteUDBInstance(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete db instance %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeleteUDBInstance", "db instance", d.Id(), err))
 		}
 
 		if _, err := client.describeDBInstanceById(d.Id()); err != nil {
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete db instance %s, %s", "DescribeUDBInstance", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeUDBInstance", "db instance", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete db instance but it still exists"))
 	})
 }
+
+// dbInstanceAttributesChanged will return true if any attribute which is updated on the running db instance is changed
+func dbInstanceAttributesChanged(d *schema.ResourceData) bool {
+	for _, key := range []string{"name", "password", "instance_type", "instance_storage", "backup_date", "backup_begin_time", "backup_black_list"} {
+		if d.HasChange(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// dbPowerStateConverge will start or stop the db instance and wait it done if the power state is not the desired one
+func dbPowerStateConverge(client *UCloudClient, dbId, zone string, running bool, timeout time.Duration) error {
+	conn := client.udbconn
+
+	db, err := client.describeDBInstanceById(dbId)
+	if err != nil {
+		return newActionError("DescribeUDBInstance", "db instance", dbId, err)
+	}
+
+	target := "Running"
+	if running && db.State == "Shutoff" {
+		req := conn.NewStartUDBInstanceRequest()
+		req.DBId = ucloud.String(dbId)
+		req.Zone = ucloud.String(zone)
+
+		if _, err := conn.StartUDBInstance(req); err != nil {
+			return newActionError("StartUDBInstance", "db instance", dbId, err)
+		}
+	} else if !running && db.State != "Shutoff" {
+		target = "Shutoff"
+		req := conn.NewStopUDBInstanceRequest()
+		req.DBId = ucloud.String(dbId)
+		req.Zone = ucloud.String(zone)
+
+		if _, err := conn.StopUDBInstance(req); err != nil {
+			return newActionError("StopUDBInstance", "db instance", dbId, err)
+		}
+	} else {
+		return nil
+	}
+
+	stateConf := client.dbWaitForState(dbId, []string{target}, timeout)
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("wait for db instance %s to be %s failed, %s", dbId, target, err)
+	}
+
+	return nil
+}
@@ -150,6 +150,64 @@ func TestAccUCloudDBInstance_backup(t *testing.T) {
 	})
 }
 
+func TestAccUCloudDBInstance_power(t *testing.T) {
+	var db udb.UDBInstanceSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_db_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckDBInstanceDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDBInstanceConfigPower, "tf-testDBInstance-power", "false", "1"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBInstanceExists("ucloud_db_instance.foo", &db),
+					resource.TestCheckResourceAttr("ucloud_db_instance.foo", "running", "false"),
+					resource.TestCheckResourceAttr("ucloud_db_instance.foo", "status", "Shutoff"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDBInstanceConfigPower, "tf-testDBInstance-powerUpdate", "false", "1"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBInstanceExists("ucloud_db_instance.foo", &db),
+					resource.TestCheckResourceAttr("ucloud_db_instance.foo", "name", "tf-testDBInstance-powerUpdate"),
+					resource.TestCheckResourceAttr("ucloud_db_instance.foo", "running", "false"),
+					resource.TestCheckResourceAttr("ucloud_db_instance.foo", "status", "Shutoff"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDBInstanceConfigPower, "tf-testDBInstance-powerUpdate", "true", "2"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBInstanceExists("ucloud_db_instance.foo", &db),
+					resource.TestCheckResourceAttr("ucloud_db_instance.foo", "running", "true"),
+					resource.TestCheckResourceAttr("ucloud_db_instance.foo", "reboot_trigger", "2"),
+					resource.TestCheckResourceAttr("ucloud_db_instance.foo", "status", "Running"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDBInstanceConfigPower, "tf-testDBInstance-powerUpdate", "true", "3"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBInstanceExists("ucloud_db_instance.foo", &db),
+					resource.TestCheckResourceAttr("ucloud_db_instance.foo", "reboot_trigger", "3"),
+					resource.TestCheckResourceAttr("ucloud_db_instance.foo", "status", "Running"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDBInstanceExists(n string, db *udb.UDBInstanceSet) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -256,6 +314,30 @@ resource "ucloud_db_instance" "foo" {
 	parameter_group_id = "${data.ucloud_db_parameter_groups.default.parameter_groups.0.id}"
 }
 `
+const testAccDBInstanceConfigPower = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_db_parameter_groups" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	engine = "mysql"
+	engine_version = "5.7"
+}
+
+resource "ucloud_db_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name = "%s"
+	instance_storage = 20
+	instance_type = "mysql-ha-1"
+	engine = "mysql"
+	engine_version = "5.7"
+	password = "2018_UClou"
+	parameter_group_id = "${data.ucloud_db_parameter_groups.default.parameter_groups.0.id}"
+	running = %s
+	reboot_trigger = "%s"
+}
+`
+
 const testAccDBInstanceConfigPgsql = `
 data "ucloud_zones" "default" {
 }
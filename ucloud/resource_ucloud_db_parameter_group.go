@@ -23,6 +23,10 @@ func resourceUCloudDBParameterGroup() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"availability_zone": &schema.Schema{
 				Type:     schema.TypeString,
@@ -220,7 +224,7 @@ func resourceUCloudDBParameterGroupRead(d *schema.ResourceData, meta interface{}
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read db parameter group, %s", "DescribeUDBParamGroup", err)
+		return newActionError("DescribeUDBParamGroup", "db parameter group", d.Id(), err)
 	}
 
 	arr := strings.Split(dbPg.DBTypeId, "-")
@@ -258,7 +262,7 @@ func resourceUCloudDBParameterGroupDelete(d *schema.ResourceData, meta interface
 		req.RegionFlag = ucloud.Bool(val.(bool))
 	}
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		_, err := client.describeDBParameterGroupByIdAndZone(d.Id(), zone)
 		if err != nil {
 			if isNotFoundError(err) {
@@ -268,14 +272,14 @@ func resourceUCloudDBParameterGroupDelete(d *schema.ResourceData, meta interface
 		}
 
 		if _, err := conn.DeleteUDBParamGroup(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete db parameter group %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeleteUDBParamGroup", "db parameter group", d.Id(), err))
 		}
 
 		if _, err := client.describeDBParameterGroupByIdAndZone(d.Id(), zone); err != nil {
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete db parameter group %s, %s", "DescribeUDBInstance", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeUDBInstance", "db parameter group", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete db parameter group but it still exists"))
@@ -22,6 +22,12 @@ func resourceUCloudDBSlave() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"master_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -194,7 +200,7 @@ func resourceUCloudDBSlaveCreate(d *schema.ResourceData, meta interface{}) error
 	d.SetId(resp.DBId)
 
 	// after create db slave, we need to wait it initialized
-	stateConf := client.dbWaitForState(d.Id(), []string{"Running"})
+	stateConf := client.dbWaitForState(d.Id(), []string{"Running"}, d.Timeout(schema.TimeoutCreate))
 
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf("wait for db slave initialize failed in create db slave %s, %s", d.Id(), err)
@@ -306,7 +312,7 @@ func resourceUCloudDBSlaveUpdate(d *schema.ResourceData, meta interface{}) error
 			}
 
 			// after stop db slave, we need to wait it stopped
-			stateConf := client.dbWaitForState(d.Id(), []string{"Shutoff"})
+			stateConf := client.dbWaitForState(d.Id(), []string{"Shutoff"}, d.Timeout(schema.TimeoutUpdate))
 
 			if _, err := stateConf.WaitForState(); err != nil {
 				return fmt.Errorf("wait for stop db slave failed in update db slave %s, %s", d.Id(), err)
@@ -318,7 +324,7 @@ func resourceUCloudDBSlaveUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 
 		// after resize db slave, we need to wait it completed
-		stateConf := client.dbWaitForState(d.Id(), []string{"Shutoff"})
+		stateConf := client.dbWaitForState(d.Id(), []string{"Shutoff"}, d.Timeout(schema.TimeoutUpdate))
 
 		if _, err := stateConf.WaitForState(); err != nil {
 			return fmt.Errorf("wait for resize db slave failed in update db %s, %s", d.Id(), err)
@@ -338,7 +344,7 @@ func resourceUCloudDBSlaveUpdate(d *schema.ResourceData, meta interface{}) error
 			}
 
 			//after start db slave, we need to wait it running
-			stateConf = client.dbWaitForState(d.Id(), []string{"Running"})
+			stateConf = client.dbWaitForState(d.Id(), []string{"Running"}, d.Timeout(schema.TimeoutUpdate))
 
 			if _, err := stateConf.WaitForState(); err != nil {
 				return fmt.Errorf("wait for start db slave failed in update db slave %s, %s", d.Id(), err)
@@ -362,7 +368,7 @@ func resourceUCloudDBSlaveUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 
 		// after change parameter group id , we need to wait it completed
-		stateConf := client.dbWaitForState(d.Id(), []string{"Running", "Shutoff"})
+		stateConf := client.dbWaitForState(d.Id(), []string{"Running", "Shutoff"}, d.Timeout(schema.TimeoutUpdate))
 
 		if _, err := stateConf.WaitForState(); err != nil {
 			return fmt.Errorf("wait for change parameter group id failed in update db slave %s, %s", d.Id(), err)
@@ -384,7 +390,7 @@ func resourceUCloudDBSlaveRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read db slave %s, %s", "DescribeUDBInstance", d.Id(), err)
+		return newActionError("DescribeUDBInstance", "db slave", d.Id(), err)
 	}
 
 	arr := strings.Split(db.DBTypeId, "-")
@@ -419,7 +425,7 @@ func resourceUCloudDBSlaveDelete(d *schema.ResourceData, meta interface{}) error
 	stopReq := conn.NewStopUDBInstanceRequest()
 	stopReq.DBId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		db, err := client.describeDBInstanceById(d.Id())
 		if err != nil {
 			if isNotFoundError(err) {
@@ -430,11 +436,11 @@ func resourceUCloudDBSlaveDelete(d *schema.ResourceData, meta interface{}) error
 
 		if db.State != "Shutoff" {
 			if _, err := conn.StopUDBInstance(stopReq); err != nil {
-				return resource.RetryableError(fmt.Errorf("do %s failed in delete db slave %s, %s", "StopUDBInstance", d.Id(), err))
+				return resource.RetryableError(newActionError("StopUDBInstance", "db slave", d.Id(), err))
 			}
 
 			// after db slave stop, we need to wait it stoped
-			stateConf := client.dbWaitForState(d.Id(), []string{"Shutoff"})
+			stateConf := client.dbWaitForState(d.Id(), []string{"Shutoff"}, d.Timeout(schema.TimeoutDelete))
 
 			if _, err := stateConf.WaitForState(); err != nil {
 				return resource.RetryableError(fmt.Errorf("wait for db slave stop failed in delete db %s, %s", d.Id(), err))
@@ -442,14 +448,14 @@ func resourceUCloudDBSlaveDelete(d *schema.ResourceData, meta interface{}) error
 		}
 
 		if _, err := conn.DeleteUDBInstance(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete db slave %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeleteUDBInstance", "db slave", d.Id(), err))
 		}
 
 		if _, err := client.describeDBInstanceById(d.Id()); err != nil {
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete db slave %s, %s", "DescribeUDBInstance", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeUDBInstance", "db slave", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete db slave but it still exists"))
@@ -19,6 +19,13 @@ func resourceUCloudDisk() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceUCloudDiskCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"availability_zone": &schema.Schema{
@@ -45,6 +52,12 @@ func resourceUCloudDisk() *schema.Resource {
 				Default:  "DataDisk",
 			},
 
+			"detach_on_resize": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"disk_charge_type": &schema.Schema{
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -58,6 +71,33 @@ func resourceUCloudDisk() *schema.Resource {
 				Default:  1,
 			},
 
+			"data_protection": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"source_snapshot_id": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"source_disk_id"},
+				DiffSuppressFunc: suppressDiskSourceDiff,
+			},
+
+			"source_disk_id": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"source_snapshot_id"},
+				DiffSuppressFunc: suppressDiskSourceDiff,
+			},
+
+			"restore_from_snapshot_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"tag": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -86,34 +126,101 @@ func resourceUCloudDiskCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*UCloudClient)
 	conn := client.udiskconn
 
-	req := conn.NewCreateUDiskRequest()
-	req.Name = ucloud.String(d.Get("name").(string))
-	req.Zone = ucloud.String(d.Get("availability_zone").(string))
-	req.Size = ucloud.Int(d.Get("disk_size").(int))
-	req.DiskType = ucloud.String(d.Get("disk_type").(string))
-	req.ChargeType = ucloud.String(d.Get("disk_charge_type").(string))
-	req.Quantity = ucloud.Int(d.Get("disk_duration").(int))
-
-	if val, ok := d.GetOk("tag"); ok {
-		req.Tag = ucloud.String(val.(string))
+	arkMode := "No"
+	if d.Get("data_protection").(bool) {
+		arkMode = "Yes"
 	}
 
-	resp, err := conn.CreateUDisk(req)
-	if err != nil {
-		return fmt.Errorf("error in create disk, %s", err)
-	}
+	if val, ok := d.GetOk("source_snapshot_id"); ok {
+		req := conn.NewCloneUDiskSnapshotRequest()
+		req.Name = ucloud.String(d.Get("name").(string))
+		req.Zone = ucloud.String(d.Get("availability_zone").(string))
+		req.SourceId = ucloud.String(val.(string))
+		req.Size = ucloud.Int(d.Get("disk_size").(int))
+		req.ChargeType = ucloud.String(d.Get("disk_charge_type").(string))
+		req.Quantity = ucloud.Int(d.Get("disk_duration").(int))
+		req.UDataArkMode = ucloud.String(arkMode)
+
+		resp, err := client.cloneDiskSnapshot(req, d.Get("tag").(string))
+		if err != nil {
+			return fmt.Errorf("error in clone disk from snapshot %s, %s", val.(string), err)
+		}
+
+		if len(resp.UDiskId) > 0 {
+			d.SetId(resp.UDiskId[0])
+		}
+	} else if val, ok := d.GetOk("source_disk_id"); ok {
+		// the size of cloned disk is the same as the source disk, it is resized later if necessary
+		sourceDiskSet, err := client.describeDiskById(val.(string))
+		if err != nil {
+			return fmt.Errorf("error in clone disk from disk %s, %s", val.(string), err)
+		}
+
+		if sourceDiskSet.Size > d.Get("disk_size").(int) {
+			return fmt.Errorf("error in clone disk from disk %s, the disk_size %d is less than the size of source disk %d", val.(string), d.Get("disk_size").(int), sourceDiskSet.Size)
+		}
+
+		req := conn.NewCloneUDiskRequest()
+		req.Name = ucloud.String(d.Get("name").(string))
+		req.Zone = ucloud.String(d.Get("availability_zone").(string))
+		req.SourceId = ucloud.String(val.(string))
+		req.ChargeType = ucloud.String(d.Get("disk_charge_type").(string))
+		req.Quantity = ucloud.Int(d.Get("disk_duration").(int))
+		req.UDataArkMode = ucloud.String(arkMode)
+
+		resp, err := client.cloneDisk(req, d.Get("tag").(string))
+		if err != nil {
+			return fmt.Errorf("error in clone disk from disk %s, %s", val.(string), err)
+		}
+
+		if len(resp.UDiskId) > 0 {
+			d.SetId(resp.UDiskId[0])
+		}
+	} else {
+		req := conn.NewCreateUDiskRequest()
+		req.Name = ucloud.String(d.Get("name").(string))
+		req.Zone = ucloud.String(d.Get("availability_zone").(string))
+		req.Size = ucloud.Int(d.Get("disk_size").(int))
+		req.DiskType = ucloud.String(d.Get("disk_type").(string))
+		req.ChargeType = ucloud.String(d.Get("disk_charge_type").(string))
+		req.Quantity = ucloud.Int(d.Get("disk_duration").(int))
+		req.UDataArkMode = ucloud.String(arkMode)
+
+		if val, ok := d.GetOk("tag"); ok {
+			req.Tag = ucloud.String(val.(string))
+		}
+
+		resp, err := conn.CreateUDisk(req)
+		if err != nil {
+			return fmt.Errorf("error in create disk, %s", err)
+		}
 
-	if len(resp.UDiskId) > 0 {
-		d.SetId(resp.UDiskId[0])
+		if len(resp.UDiskId) > 0 {
+			d.SetId(resp.UDiskId[0])
+		}
 	}
 
 	// after create disk, we need to wait it initialized
-	stateConf := diskWaitForState(client, d.Id())
+	stateConf := diskWaitForState(client, d.Id(), d.Timeout(schema.TimeoutCreate))
 
-	if _, err = stateConf.WaitForState(); err != nil {
+	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf("wait for disk initialize failed in create disk %s, %s", d.Id(), err)
 	}
 
+	// the disk cloned from another disk need to be resized to the expected size
+	if _, ok := d.GetOk("source_disk_id"); ok {
+		diskSet, err := client.describeDiskById(d.Id())
+		if err != nil {
+			return fmt.Errorf("error in create disk %s, %s", d.Id(), err)
+		}
+
+		if size := d.Get("disk_size").(int); diskSet.Size < size {
+			if err := diskResize(client, d.Id(), d.Get("availability_zone").(string), size, "Available", d.Timeout(schema.TimeoutCreate)); err != nil {
+				return fmt.Errorf("error in create disk %s, %s", d.Id(), err)
+			}
+		}
+	}
+
 	return resourceUCloudDiskUpdate(d, meta)
 }
 
@@ -139,22 +246,40 @@ func resourceUCloudDiskUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	if d.HasChange("disk_size") && !d.IsNewResource() {
 		d.SetPartial("disk_size")
-		req := conn.NewResizeUDiskRequest()
+		if err := resourceUCloudDiskResize(d, client); err != nil {
+			return fmt.Errorf("error in update disk %s, %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("data_protection") && !d.IsNewResource() {
+		d.SetPartial("data_protection")
+		req := conn.NewSetUDiskUDataArkModeRequest()
 		req.Zone = ucloud.String(d.Get("availability_zone").(string))
 		req.UDiskId = ucloud.String(d.Id())
-		req.Size = ucloud.Int(d.Get("disk_size").(int))
+		req.UDataArkMode = ucloud.String("No")
+		if d.Get("data_protection").(bool) {
+			req.UDataArkMode = ucloud.String("Yes")
+		}
 
-		_, err := conn.ResizeUDisk(req)
+		_, err := conn.SetUDiskUDataArkMode(req)
 
 		if err != nil {
-			return fmt.Errorf("do %s failed in update disk %s, %s", "ResizeUDisk", d.Id(), err)
+			return fmt.Errorf("do %s failed in update disk %s, %s", "SetUDiskUDataArkMode", d.Id(), err)
 		}
 
-		// after update disk size, we need to wait it completed
-		stateConf := diskWaitForState(client, d.Id())
+		// after set data ark mode, we need to wait it completed
+		stateConf := diskDataProtectionWaitForState(client, d.Id(), d.Get("data_protection").(bool), d.Timeout(schema.TimeoutUpdate))
 
 		if _, err = stateConf.WaitForState(); err != nil {
-			return fmt.Errorf("wait for disk update size failed in update disk %s, %s", d.Id(), err)
+			return fmt.Errorf("wait for disk set data ark mode failed in update disk %s, %s", d.Id(), err)
+		}
+	}
+
+	// the disk is rolled back in place, the snapshot must be created from the same disk
+	if d.HasChange("restore_from_snapshot_id") && d.Get("restore_from_snapshot_id").(string) != "" && !d.IsNewResource() {
+		d.SetPartial("restore_from_snapshot_id")
+		if err := resourceUCloudDiskRestore(d, client); err != nil {
+			return fmt.Errorf("error in update disk %s, %s", d.Id(), err)
 		}
 	}
 
@@ -173,7 +298,7 @@ func resourceUCloudDiskRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read disk %s, %s", "DescribeUDisk", d.Id(), err)
+		return newActionError("DescribeUDisk", "disk", d.Id(), err)
 	}
 
 	d.Set("name", diskSet.Name)
@@ -181,6 +306,7 @@ func resourceUCloudDiskRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("disk_size", diskSet.Size)
 	d.Set("disk_type", diskSet.DiskType)
 	d.Set("disk_charge_type", diskSet.ChargeType)
+	d.Set("data_protection", diskSet.UDataArkMode == "Yes")
 	d.Set("create_time", timestampToString(diskSet.CreateTime))
 	d.Set("expire_time", timestampToString(diskSet.ExpiredTime))
 	d.Set("status", diskSet.Status)
@@ -196,9 +322,9 @@ func resourceUCloudDiskDelete(d *schema.ResourceData, meta interface{}) error {
 	req.Zone = ucloud.String(d.Get("availability_zone").(string))
 	req.UDiskId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if _, err := conn.DeleteUDisk(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete disk %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeleteUDisk", "disk", d.Id(), err))
 		}
 
 		_, err := client.describeDiskById(d.Id())
@@ -207,18 +333,222 @@ func resourceUCloudDiskDelete(d *schema.ResourceData, meta interface{}) error {
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete disk %s, %s", "DescribeUDisk", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeUDisk", "disk", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete disk but it still exists"))
 	})
 }
 
-func diskWaitForState(client *UCloudClient, diskId string) *resource.StateChangeConf {
+// resourceUCloudDiskCustomizeDiff will reject to shrink the disk, because it is not supported by api,
+// and it will reject to set restore_from_snapshot_id on create, because there is no snapshot of the disk to restore from yet.
+func resourceUCloudDiskCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		if _, ok := diff.GetOk("restore_from_snapshot_id"); ok || !diff.NewValueKnown("restore_from_snapshot_id") {
+			return fmt.Errorf("restore_from_snapshot_id cannot be set to create disk, please use source_snapshot_id instead")
+		}
+		return nil
+	}
+
+	if !diff.HasChange("disk_size") {
+		return nil
+	}
+
+	if o, n := diff.GetChange("disk_size"); n.(int) < o.(int) {
+		return fmt.Errorf("disk_size of disk %s cannot be shrunk from %d to %d, please create a new disk instead", diff.Id(), o.(int), n.(int))
+	}
+
+	return nil
+}
+
+// resourceUCloudDiskResize will resize the disk, if the disk is attached and detach_on_resize is set,
+// it is detached before resize and attached to the same instance again after resize.
+func resourceUCloudDiskResize(d *schema.ResourceData, client *UCloudClient) error {
+	diskId := d.Id()
+	zone := d.Get("availability_zone").(string)
+	size := d.Get("disk_size").(int)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	diskSet, err := client.describeDiskById(diskId)
+	if err != nil {
+		return fmt.Errorf("do %s failed, %s", "DescribeUDisk", err)
+	}
+
+	instanceId := diskSet.UHostId
+	if instanceId == "" {
+		return diskResize(client, diskId, zone, size, "Available", timeout)
+	}
+
+	// the attachment changes on the same instance are serialized
+	ucloudMutexKV.Lock(instanceId)
+	defer ucloudMutexKV.Unlock(instanceId)
+
+	// some kinds of disk could be resized online without detaching
+	if !d.Get("detach_on_resize").(bool) {
+		return diskResize(client, diskId, zone, size, "InUse", timeout)
+	}
+
+	if err := diskDetach(client, diskId, instanceId, zone, timeout); err != nil {
+		return err
+	}
+
+	if err := diskResize(client, diskId, zone, size, "Available", timeout); err != nil {
+		return fmt.Errorf("%s, the disk is detached from instance %s and should be attached again manually", err, instanceId)
+	}
+
+	if err := diskAttach(client, diskId, instanceId, zone, timeout); err != nil {
+		return fmt.Errorf("%s, the disk is resized but not attached to instance %s again", err, instanceId)
+	}
+
+	return nil
+}
+
+// resourceUCloudDiskRestore will roll the disk back to the snapshot, the disk must be detached before restore,
+// so if it is attached, it is detached before restore and attached to the same instance again after restore.
+func resourceUCloudDiskRestore(d *schema.ResourceData, client *UCloudClient) error {
+	diskId := d.Id()
+	zone := d.Get("availability_zone").(string)
+	snapshotId := d.Get("restore_from_snapshot_id").(string)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	diskSet, err := client.describeDiskById(diskId)
+	if err != nil {
+		return fmt.Errorf("do %s failed, %s", "DescribeUDisk", err)
+	}
+
+	instanceId := diskSet.UHostId
+	if instanceId == "" {
+		return diskRestore(client, diskId, zone, snapshotId, timeout)
+	}
+
+	// the attachment changes on the same instance are serialized
+	ucloudMutexKV.Lock(instanceId)
+	defer ucloudMutexKV.Unlock(instanceId)
+
+	if err := diskDetach(client, diskId, instanceId, zone, timeout); err != nil {
+		return err
+	}
+
+	if err := diskRestore(client, diskId, zone, snapshotId, timeout); err != nil {
+		return fmt.Errorf("%s, the disk is detached from instance %s and should be attached again manually", err, instanceId)
+	}
+
+	if err := diskAttach(client, diskId, instanceId, zone, timeout); err != nil {
+		return fmt.Errorf("%s, the disk is restored but not attached to instance %s again", err, instanceId)
+	}
+
+	return nil
+}
+
+// diskRestore will roll the detached disk back to the snapshot and wait it available
+func diskRestore(client *UCloudClient, diskId, zone, snapshotId string, timeout time.Duration) error {
+	conn := client.udiskconn
+
+	req := conn.NewRestoreUDiskRequest()
+	req.Zone = ucloud.String(zone)
+	req.UDiskId = ucloud.String(diskId)
+	req.SnapshotId = ucloud.String(snapshotId)
+
+	if _, err := conn.RestoreUDisk(req); err != nil {
+		return fmt.Errorf("do %s failed, %s", "RestoreUDisk", err)
+	}
+
+	// after restore disk, we need to wait it completed
+	stateConf := diskWaitForState(client, diskId, timeout)
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("wait for disk restore failed, %s", err)
+	}
+
+	return nil
+}
+
+// diskResize will resize the disk and wait it back to the status
+func diskResize(client *UCloudClient, diskId, zone string, size int, status string, timeout time.Duration) error {
+	conn := client.udiskconn
+
+	req := conn.NewResizeUDiskRequest()
+	req.Zone = ucloud.String(zone)
+	req.UDiskId = ucloud.String(diskId)
+	req.Size = ucloud.Int(size)
+
+	if _, err := conn.ResizeUDisk(req); err != nil {
+		return fmt.Errorf("do %s failed, %s", "ResizeUDisk", err)
+	}
+
+	// after update disk size, we need to wait it completed
+	stateConf := diskStatusWaitForState(client, diskId, status, timeout)
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("wait for disk update size failed, %s", err)
+	}
+
+	return nil
+}
+
+// diskDetach will detach the disk from instance and wait it available
+func diskDetach(client *UCloudClient, diskId, instanceId, zone string, timeout time.Duration) error {
+	conn := client.udiskconn
+
+	req := conn.NewDetachUDiskRequest()
+	req.Zone = ucloud.String(zone)
+	req.UDiskId = ucloud.String(diskId)
+	req.UHostId = ucloud.String(instanceId)
+
+	if _, err := conn.DetachUDisk(req); err != nil {
+		return fmt.Errorf("do %s failed, %s", "DetachUDisk", err)
+	}
+
+	// after detach disk, we need to wait it completed
+	stateConf := diskStatusWaitForState(client, diskId, "Available", timeout)
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("wait for disk detach failed, %s", err)
+	}
+
+	return nil
+}
+
+// diskAttach will attach the disk to instance and wait it in use
+func diskAttach(client *UCloudClient, diskId, instanceId, zone string, timeout time.Duration) error {
+	conn := client.udiskconn
+
+	req := conn.NewAttachUDiskRequest()
+	req.Zone = ucloud.String(zone)
+	req.UDiskId = ucloud.String(diskId)
+	req.UHostId = ucloud.String(instanceId)
+
+	if _, err := conn.AttachUDisk(req); err != nil {
+		return fmt.Errorf("do %s failed, %s", "AttachUDisk", err)
+	}
+
+	// after attach disk, we need to wait it completed
+	stateConf := diskStatusWaitForState(client, diskId, "InUse", timeout)
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("wait for disk attach failed, %s", err)
+	}
+
+	return nil
+}
+
+// suppressDiskSourceDiff will ignore the change of the source of disk after import,
+// because the source is not returned by api, it should not force a new disk.
+func suppressDiskSourceDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == ""
+}
+
+func diskWaitForState(client *UCloudClient, diskId string, timeout time.Duration) *resource.StateChangeConf {
+	return diskStatusWaitForState(client, diskId, "Available", timeout)
+}
+
+// diskStatusWaitForState will wait the disk to be the status, such as "Available" and "InUse"
+func diskStatusWaitForState(client *UCloudClient, diskId, status string, timeout time.Duration) *resource.StateChangeConf {
+	target := strings.ToLower(status)
 	return &resource.StateChangeConf{
 		Pending:    []string{"pending"},
-		Target:     []string{"available"},
-		Timeout:    10 * time.Minute,
+		Target:     []string{target},
+		Timeout:    timeout,
 		Delay:      5 * time.Second,
 		MinTimeout: 3 * time.Second,
 		Refresh: func() (interface{}, string, error) {
@@ -230,8 +560,12 @@ func diskWaitForState(client *UCloudClient, diskId string) *resource.StateChange
 				return nil, "", err
 			}
 
+			if diskSet.Status == "Failed" || diskSet.Status == "RestoreFailed" {
+				return nil, "", fmt.Errorf("disk is %s, please check the source of disk", diskSet.Status)
+			}
+
 			state := strings.ToLower(diskSet.Status)
-			if state != "available" {
+			if state != target {
 				state = "pending"
 			}
 
@@ -239,3 +573,27 @@ func diskWaitForState(client *UCloudClient, diskId string) *resource.StateChange
 		},
 	}
 }
+
+// diskDataProtectionWaitForState will wait the data ark mode of disk to be the expected one,
+// the disk may be attached, so that both "Available" and "InUse" are regarded as completed.
+func diskDataProtectionWaitForState(client *UCloudClient, diskId string, enabled bool, timeout time.Duration) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     []string{"completed"},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			diskSet, err := client.describeDiskById(diskId)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if (diskSet.UDataArkMode == "Yes") != enabled || checkStringIn(diskSet.Status, []string{"Available", "InUse"}) != nil {
+				return diskSet, "pending", nil
+			}
+
+			return diskSet, "completed", nil
+		},
+	}
+}
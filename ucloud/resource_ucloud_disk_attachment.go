@@ -15,7 +15,16 @@ func resourceUCloudDiskAttachment() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceUCloudDiskAttachmentCreate,
 		Read:   resourceUCloudDiskAttachmentRead,
+		Update: resourceUCloudDiskAttachmentUpdate,
 		Delete: resourceUCloudDiskAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceUCloudDiskAttachmentImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"availability_zone": &schema.Schema{
@@ -35,6 +44,17 @@ func resourceUCloudDiskAttachment() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+
+			"stop_instance_before_detaching": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"device_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -46,6 +66,10 @@ func resourceUCloudDiskAttachmentCreate(d *schema.ResourceData, meta interface{}
 	instanceId := d.Get("instance_id").(string)
 	diskId := d.Get("disk_id").(string)
 
+	// the attachment changes on the same instance are serialized
+	ucloudMutexKV.Lock(instanceId)
+	defer ucloudMutexKV.Unlock(instanceId)
+
 	req := conn.NewAttachUDiskRequest()
 	req.Zone = ucloud.String(d.Get("availability_zone").(string))
 	req.UHostId = ucloud.String(instanceId)
@@ -63,7 +87,7 @@ func resourceUCloudDiskAttachmentCreate(d *schema.ResourceData, meta interface{}
 		Pending:    []string{"attaching"},
 		Target:     []string{"inuse"},
 		Refresh:    diskAttachmentStateRefreshFunc(client, diskId),
-		Timeout:    10 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutCreate),
 		Delay:      5 * time.Second,
 		MinTimeout: 3 * time.Second,
 	}
@@ -90,17 +114,39 @@ func resourceUCloudDiskAttachmentRead(d *schema.ResourceData, meta interface{})
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read disk attachment %s, %s", "DescribeUDisk", d.Id(), err)
+		return newActionError("DescribeUDisk", "disk attachment", d.Id(), err)
 	}
 
-	d.Set("availability_zone", d.Get("availability_zone").(string))
+	d.Set("availability_zone", resourceSet.Zone)
 	d.Set("instance_id", resourceSet.UHostId)
 	d.Set("disk_id", resourceSet.UDiskId)
+	d.Set("device_name", resourceSet.DeviceName)
 
 	return nil
 }
 
-func resourceUCloudDiskAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+// resourceUCloudDiskAttachmentUpdate only keeps the stop_instance_before_detaching in state, it is used by deleting.
+func resourceUCloudDiskAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceUCloudDiskAttachmentRead(d, meta)
+}
+
+// resourceUCloudDiskAttachmentImport will import the disk attachment by the id formatted as "disk-id:instance-id".
+func resourceUCloudDiskAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseAssociationInfo(d.Id()); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid id of disk attachment %q, should be formatted as \"disk-id:instance-id\"", d.Id())
+	}
+
+	d.SetId(fmt.Sprintf("disk#%s:uhost#%s", parts[0], parts[1]))
+	d.Set("stop_instance_before_detaching", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceUCloudDiskAttachmentDelete(d *schema.ResourceData, meta interface{}) (rerr error) {
 	client := meta.(*UCloudClient)
 	conn := client.udiskconn
 
@@ -109,16 +155,59 @@ func resourceUCloudDiskAttachmentDelete(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("error in parse disk attachment %s, %s", d.Id(), err)
 	}
 
+	// the attachment changes on the same instance are serialized
+	ucloudMutexKV.Lock(attach.ResourceId)
+	defer ucloudMutexKV.Unlock(attach.ResourceId)
+
+	// some kinds of disk must be detached when the instance is stopped, it is started again after detached
+	if d.Get("stop_instance_before_detaching").(bool) {
+		instance, err := client.describeInstanceById(attach.ResourceId)
+		if err != nil {
+			return newActionError("DescribeUHostInstance", "instance", attach.ResourceId, err)
+		}
+
+		if instance.State != "Stopped" {
+			if err := instancePowerStateConverge(client, attach.ResourceId, false, d.Timeout(schema.TimeoutDelete)); err != nil {
+				return fmt.Errorf("error in delete disk attachment %s, %s", d.Id(), err)
+			}
+
+			defer func() {
+				if err := instancePowerStateConverge(client, attach.ResourceId, true, d.Timeout(schema.TimeoutDelete)); err != nil && rerr == nil {
+					rerr = fmt.Errorf("error in delete disk attachment %s, %s", d.Id(), err)
+				}
+			}()
+		}
+	}
+
 	req := conn.NewDetachUDiskRequest()
 	req.Zone = ucloud.String(d.Get("availability_zone").(string))
 	req.UDiskId = ucloud.String(attach.PrimaryId)
 	req.UHostId = ucloud.String(attach.ResourceId)
 
-	return resource.Retry(15*time.Minute, func() *resource.RetryError {
+	// the detaching is issued again if it is not completed in time, so that it must be shorter than the timeout of delete
+	detachTimeout := d.Timeout(schema.TimeoutDelete) * 2 / 3
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		// the detaching may be completed by the previous attempt, the disk should not be detached again
+		diskSet, err := client.describeDiskById(attach.PrimaryId)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil
+			}
+			return resource.RetryableError(newActionError("DescribeUDisk", "disk", attach.PrimaryId, err))
+		}
+
+		if strings.ToLower(diskSet.Status) == "available" || diskSet.UHostId != attach.ResourceId {
+			return nil
+		}
+
+		// the disk may be busy in another operation, such as attaching or creating snapshot,
+		// and the error of network is also retryable, only the other errors returned by api are fatal
 		if _, err := conn.DetachUDisk(req); err != nil {
-			if uErr, ok := err.(uerr.Error); ok && uErr.Code() != 17060 {
-				return resource.NonRetryableError(fmt.Errorf("error in delete disk attachment %s, %s", d.Id(), err))
+			if uerr.IsCodeError(err) && !isResourceInUseError(err) {
+				return resource.NonRetryableError(newActionError("DetachUDisk", "disk attachment", d.Id(), err))
 			}
+			return resource.RetryableError(newActionError("DetachUDisk", "disk attachment", d.Id(), err))
 		}
 
 		// after detach disk, we need to wait it completed
@@ -126,7 +215,7 @@ func resourceUCloudDiskAttachmentDelete(d *schema.ResourceData, meta interface{}
 			Pending:    []string{"detaching"},
 			Target:     []string{"available"},
 			Refresh:    diskAttachmentStateRefreshFunc(client, attach.PrimaryId),
-			Timeout:    10 * time.Minute,
+			Timeout:    detachTimeout,
 			Delay:      5 * time.Second,
 			MinTimeout: 3 * time.Second,
 		}
@@ -33,12 +33,69 @@ func TestAccUCloudDiskAttachment_basic(t *testing.T) {
 					testAccCheckDiskExists("ucloud_disk.foo", &diskSet),
 					testAccCheckInstanceExists("ucloud_instance.foo", &instance),
 					testAccCheckDiskAttachmentExists("ucloud_disk_attachment.foo", &diskSet, &instance),
+					resource.TestCheckResourceAttr("ucloud_disk_attachment.foo", "device_name", "/dev/vdb"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccUCloudDiskAttachment_multiple(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDiskAttachmentDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDiskAttachmentConfigMultiple,
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ucloud_disk_attachment.foo", "device_name"),
+					resource.TestCheckResourceAttrSet("ucloud_disk_attachment.bar", "device_name"),
+					resource.TestCheckResourceAttr("ucloud_disk_attachment.foo", "stop_instance_before_detaching", "true"),
+					testAccCheckDiskAttachmentDeviceNamesUnique("ucloud_disk_attachment.foo", "ucloud_disk_attachment.bar"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "ucloud_disk_attachment.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"stop_instance_before_detaching"},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["ucloud_disk_attachment.foo"]
+					if !ok {
+						return "", fmt.Errorf("not found: %s", "ucloud_disk_attachment.foo")
+					}
+					return fmt.Sprintf("%s:%s", rs.Primary.Attributes["disk_id"], rs.Primary.Attributes["instance_id"]), nil
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckDiskAttachmentDeviceNamesUnique(names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		seen := map[string]string{}
+		for _, n := range names {
+			rs, ok := s.RootModule().Resources[n]
+			if !ok {
+				return fmt.Errorf("not found: %s", n)
+			}
+
+			deviceName := rs.Primary.Attributes["device_name"]
+			if other, ok := seen[deviceName]; ok {
+				return fmt.Errorf("device name %s of %s is the same as %s", deviceName, n, other)
+			}
+			seen[deviceName] = n
+		}
+		return nil
+	}
+}
+
 func testAccCheckDiskAttachmentExists(n string, diskSet *udisk.UDiskDataSet, instance *uhost.UHostInstanceSet) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -128,3 +185,48 @@ resource "ucloud_disk_attachment" "foo" {
 	instance_id = "${ucloud_instance.foo.id}"
 }
 `
+
+const testAccDiskAttachmentConfigMultiple = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_disk" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name = "testAccMultiple"
+	disk_size = 10
+}
+
+resource "ucloud_disk" "bar" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name = "testAccMultiple"
+	disk_size = 10
+}
+
+resource "ucloud_instance" "foo" {
+	name = "tf-testAccDiskAttachmentMultiple"
+	instance_type = "n-highcpu-1"
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+}
+
+resource "ucloud_disk_attachment" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	disk_id = "${ucloud_disk.foo.id}"
+	instance_id = "${ucloud_instance.foo.id}"
+	stop_instance_before_detaching = true
+}
+
+resource "ucloud_disk_attachment" "bar" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	disk_id = "${ucloud_disk.bar.id}"
+	instance_id = "${ucloud_instance.foo.id}"
+	stop_instance_before_detaching = true
+}
+`
@@ -0,0 +1,228 @@
+package ucloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/ucloud/ucloud-sdk-go/ucloud"
+)
+
+func resourceUCloudDiskSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUCloudDiskSnapshotCreate,
+		Read:   resourceUCloudDiskSnapshotRead,
+		Delete: resourceUCloudDiskSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"disk_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDiskName,
+			},
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"availability_zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"disk_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"disk_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"expire_time": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceUCloudDiskSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*UCloudClient)
+	conn := client.udiskconn
+
+	diskId := d.Get("disk_id").(string)
+
+	// the zone is required by api, it is the zone of disk by default
+	zone := d.Get("availability_zone").(string)
+	if zone == "" {
+		diskSet, err := client.describeDiskById(diskId)
+		if err != nil {
+			return fmt.Errorf("error in create disk snapshot, %s", err)
+		}
+		zone = diskSet.Zone
+	}
+
+	req := conn.NewCreateUDiskSnapshotRequest()
+	req.Zone = ucloud.String(zone)
+	req.UDiskId = ucloud.String(diskId)
+	req.Name = ucloud.String(d.Get("name").(string))
+
+	if val, ok := d.GetOk("comment"); ok {
+		req.Comment = ucloud.String(val.(string))
+	}
+
+	resp, err := conn.CreateUDiskSnapshot(req)
+	if err != nil {
+		return fmt.Errorf("error in create disk snapshot, %s", err)
+	}
+
+	if len(resp.SnapshotId) > 0 {
+		d.SetId(resp.SnapshotId[0])
+	}
+	d.Set("availability_zone", zone)
+
+	// after create disk snapshot, we need to wait it normal
+	stateConf := diskSnapshotWaitForState(client, d.Id(), d.Timeout(schema.TimeoutCreate))
+
+	if _, err = stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("wait for disk snapshot normal failed in create disk snapshot %s, %s", d.Id(), err)
+	}
+
+	return resourceUCloudDiskSnapshotRead(d, meta)
+}
+
+func resourceUCloudDiskSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*UCloudClient)
+
+	snapshot, err := client.describeDiskSnapshotById(d.Id())
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return newActionError("DescribeUDiskSnapshot", "disk_snapshot", d.Id(), err)
+	}
+
+	// the zone is not returned by api, it is read from the disk after import
+	if d.Get("availability_zone").(string) == "" {
+		diskSet, err := client.describeDiskById(snapshot.UDiskId)
+		if err != nil {
+			return newActionError("DescribeUDisk", "disk", snapshot.UDiskId, err)
+		}
+		d.Set("availability_zone", diskSet.Zone)
+	}
+
+	d.Set("disk_id", snapshot.UDiskId)
+	d.Set("name", snapshot.Name)
+	d.Set("comment", snapshot.Comment)
+	d.Set("disk_name", snapshot.UDiskName)
+	d.Set("disk_type", diskSnapshotTypeToString(snapshot.DiskType))
+	d.Set("size", snapshot.Size)
+	d.Set("status", snapshot.Status)
+	d.Set("create_time", timestampToString(snapshot.CreateTime))
+	d.Set("expire_time", timestampToString(snapshot.ExpiredTime))
+
+	return nil
+}
+
+func resourceUCloudDiskSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*UCloudClient)
+	conn := client.udiskconn
+
+	req := conn.NewDeleteUDiskSnapshotRequest()
+	req.Zone = ucloud.String(d.Get("availability_zone").(string))
+	req.SnapshotId = ucloud.String(d.Id())
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		if _, err := conn.DeleteUDiskSnapshot(req); err != nil {
+			if isResourceInUseError(err) {
+				return resource.RetryableError(newActionError("DeleteUDiskSnapshot", "disk_snapshot", d.Id(), err))
+			}
+			return resource.NonRetryableError(newActionError("DeleteUDiskSnapshot", "disk_snapshot", d.Id(), err))
+		}
+
+		if _, err := client.describeDiskSnapshotById(d.Id()); err != nil {
+			if isNotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(newActionError("DescribeUDiskSnapshot", "disk_snapshot", d.Id(), err))
+		}
+
+		return resource.RetryableError(fmt.Errorf("delete disk snapshot but it still exists"))
+	})
+}
+
+// diskSnapshotTypeToString will convert the type of source disk of snapshot, 0 is data disk and 1 is system disk
+func diskSnapshotTypeToString(diskType int) string {
+	if diskType == 1 {
+		return "SystemDisk"
+	}
+	return "DataDisk"
+}
+
+func diskSnapshotWaitForState(client *UCloudClient, snapshotId string, timeout time.Duration) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     []string{"Normal"},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			snapshot, err := client.describeDiskSnapshotById(snapshotId)
+			if err != nil {
+				if isNotFoundError(err) {
+					return nil, "pending", nil
+				}
+				return nil, "", err
+			}
+
+			state := snapshot.Status
+			if state == "Failed" {
+				return nil, "", fmt.Errorf("disk snapshot is failed, please check the status of disk")
+			}
+
+			if state != "Normal" {
+				state = "pending"
+			}
+
+			return snapshot, state, nil
+		},
+	}
+}
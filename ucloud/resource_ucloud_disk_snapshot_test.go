@@ -0,0 +1,113 @@
+package ucloud
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/ucloud/ucloud-sdk-go/services/udisk"
+)
+
+func TestAccUCloudDiskSnapshot_basic(t *testing.T) {
+	var snapshot udisk.UDiskSnapshotSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_disk_snapshot.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckDiskSnapshotDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDiskSnapshotConfig,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDiskSnapshotExists("ucloud_disk_snapshot.foo", &snapshot),
+					resource.TestCheckResourceAttr("ucloud_disk_snapshot.foo", "name", "tf-testAccDiskSnapshot"),
+					resource.TestCheckResourceAttr("ucloud_disk_snapshot.foo", "comment", "before upgrade"),
+					resource.TestCheckResourceAttr("ucloud_disk_snapshot.foo", "status", "Normal"),
+					resource.TestCheckResourceAttr("ucloud_disk_snapshot.foo", "size", "10"),
+					resource.TestCheckResourceAttr("ucloud_disk_snapshot.foo", "disk_type", "DataDisk"),
+					resource.TestCheckResourceAttr("ucloud_disk_snapshot.foo", "availability_zone", "cn-sh2-02"),
+					resource.TestCheckResourceAttrPair("ucloud_disk_snapshot.foo", "disk_id", "ucloud_disk.foo", "id"),
+					resource.TestCheckResourceAttrPair("ucloud_disk_snapshot.foo", "disk_name", "ucloud_disk.foo", "name"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "ucloud_disk_snapshot.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDiskSnapshotExists(n string, snapshot *udisk.UDiskSnapshotSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("disk snapshot id is empty")
+		}
+
+		client := testAccProvider.Meta().(*UCloudClient)
+		ptr, err := client.describeDiskSnapshotById(rs.Primary.ID)
+
+		log.Printf("[INFO] disk snapshot id %#v", rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		*snapshot = *ptr
+		return nil
+	}
+}
+
+func testAccCheckDiskSnapshotDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ucloud_disk_snapshot" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*UCloudClient)
+		snapshot, err := client.describeDiskSnapshotById(rs.Primary.ID)
+
+		// Verify the error is what we want
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+
+		if snapshot.SnapshotId != "" {
+			return fmt.Errorf("disk snapshot still exist")
+		}
+	}
+
+	return nil
+}
+
+const testAccDiskSnapshotConfig = `
+resource "ucloud_disk" "foo" {
+	availability_zone = "cn-sh2-02"
+	name = "testAccDiskSnapshot"
+	disk_size = 10
+}
+
+resource "ucloud_disk_snapshot" "foo" {
+	disk_id = "${ucloud_disk.foo.id}"
+	name = "tf-testAccDiskSnapshot"
+	comment = "before upgrade"
+}
+`
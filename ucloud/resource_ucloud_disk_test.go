@@ -3,6 +3,7 @@ package ucloud
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -49,6 +50,208 @@ func TestAccUCloudDisk_basic(t *testing.T) {
 
 }
 
+func TestAccUCloudDisk_dataProtection(t *testing.T) {
+	var diskSet udisk.UDiskDataSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_disk.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckDiskDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDiskConfigDataProtection, "true"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDiskExists("ucloud_disk.foo", &diskSet),
+					resource.TestCheckResourceAttr("ucloud_disk.foo", "data_protection", "true"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDiskConfigDataProtection, "false"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDiskExists("ucloud_disk.foo", &diskSet),
+					resource.TestCheckResourceAttr("ucloud_disk.foo", "data_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudDisk_clone(t *testing.T) {
+	var diskSet udisk.UDiskDataSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDiskDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDiskConfigClone,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDiskExists("ucloud_disk.from_snapshot", &diskSet),
+					resource.TestCheckResourceAttr("ucloud_disk.from_snapshot", "disk_size", "20"),
+					resource.TestCheckResourceAttr("ucloud_disk.from_snapshot", "status", "Available"),
+					resource.TestCheckResourceAttrPair("ucloud_disk.from_snapshot", "source_snapshot_id", "ucloud_disk_snapshot.foo", "id"),
+					resource.TestCheckResourceAttr("ucloud_disk.from_snapshot", "tag", "tf-acc"),
+					testAccCheckDiskExists("ucloud_disk.from_disk", &diskSet),
+					resource.TestCheckResourceAttr("ucloud_disk.from_disk", "disk_size", "30"),
+					resource.TestCheckResourceAttr("ucloud_disk.from_disk", "status", "Available"),
+					resource.TestCheckResourceAttrPair("ucloud_disk.from_disk", "source_disk_id", "ucloud_disk.foo", "id"),
+					resource.TestCheckResourceAttr("ucloud_disk.from_disk", "tag", "tf-acc"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudDisk_restore(t *testing.T) {
+	var diskSet udisk.UDiskDataSet
+	var updated udisk.UDiskDataSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDiskDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDiskConfigRestore,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDiskExists("ucloud_disk.foo", &diskSet),
+				),
+			},
+			resource.TestStep{
+				Config: testAccDiskConfigRestoreUpdate,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDiskExists("ucloud_disk.foo", &updated),
+					testAccCheckDiskNotRecreated(&diskSet, &updated),
+					resource.TestCheckResourceAttrPair("ucloud_disk.foo", "restore_from_snapshot_id", "ucloud_disk_snapshot.foo", "id"),
+					resource.TestCheckResourceAttr("ucloud_disk.foo", "status", "Available"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudDisk_restoreOnCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDiskDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccDiskConfigRestoreOnCreate,
+				ExpectError: regexp.MustCompile("restore_from_snapshot_id cannot be set to create disk"),
+			},
+		},
+	})
+}
+
+func TestAccUCloudDisk_restoreAttached(t *testing.T) {
+	var diskSet udisk.UDiskDataSet
+	var updated udisk.UDiskDataSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDiskDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDiskConfigRestoreAttached, ""),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDiskExists("ucloud_disk.foo", &diskSet),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDiskConfigRestoreAttached, testAccDiskConfigRestoreAttachedSnapshot),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDiskExists("ucloud_disk.foo", &updated),
+					testAccCheckDiskNotRecreated(&diskSet, &updated),
+					resource.TestCheckResourceAttrPair("ucloud_disk.foo", "restore_from_snapshot_id", "ucloud_disk_snapshot.foo", "id"),
+					resource.TestCheckResourceAttr("ucloud_disk.foo", "status", "InUse"),
+					resource.TestCheckResourceAttrPair("ucloud_disk_attachment.foo", "instance_id", "ucloud_instance.foo", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudDisk_resizeAttached(t *testing.T) {
+	var diskSet udisk.UDiskDataSet
+	var updated udisk.UDiskDataSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDiskDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDiskConfigResizeAttached, 10),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDiskExists("ucloud_disk.foo", &diskSet),
+					resource.TestCheckResourceAttr("ucloud_disk.foo", "disk_size", "10"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDiskConfigResizeAttached, 20),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDiskExists("ucloud_disk.foo", &updated),
+					testAccCheckDiskNotRecreated(&diskSet, &updated),
+					resource.TestCheckResourceAttr("ucloud_disk.foo", "disk_size", "20"),
+					resource.TestCheckResourceAttr("ucloud_disk.foo", "status", "InUse"),
+					resource.TestCheckResourceAttrPair("ucloud_disk_attachment.foo", "instance_id", "ucloud_instance.foo", "id"),
+				),
+			},
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccDiskConfigResizeAttached, 10),
+				ExpectError: regexp.MustCompile("cannot be shrunk"),
+			},
+		},
+	})
+}
+
+func testAccCheckDiskNotRecreated(before, after *udisk.UDiskDataSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.UDiskId != after.UDiskId {
+			return fmt.Errorf("disk is recreated from %s to %s", before.UDiskId, after.UDiskId)
+		}
+		return nil
+	}
+}
+
 func testAccCheckDiskExists(n string, diskSet *udisk.UDiskDataSet) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -124,3 +327,169 @@ resource "ucloud_disk" "foo" {
 	disk_size = 20
 }
 `
+
+const testAccDiskConfigDataProtection = `
+resource "ucloud_disk" "foo" {
+	availability_zone = "cn-sh2-02"
+	name = "testAccDataProtection"
+	disk_size = 10
+	data_protection = %s
+}
+`
+
+const testAccDiskConfigClone = `
+resource "ucloud_disk" "foo" {
+	availability_zone = "cn-sh2-02"
+	name = "testAccDiskClone"
+	disk_size = 10
+}
+
+resource "ucloud_disk_snapshot" "foo" {
+	disk_id = "${ucloud_disk.foo.id}"
+	name = "tf-testAccDiskClone"
+}
+
+resource "ucloud_disk" "from_snapshot" {
+	availability_zone = "cn-sh2-02"
+	name = "testAccDiskCloneFromSnapshot"
+	disk_size = 20
+	source_snapshot_id = "${ucloud_disk_snapshot.foo.id}"
+	tag = "tf-acc"
+}
+
+resource "ucloud_disk" "from_disk" {
+	availability_zone = "cn-sh2-02"
+	name = "testAccDiskCloneFromDisk"
+	disk_size = 30
+	source_disk_id = "${ucloud_disk.foo.id}"
+	tag = "tf-acc"
+}
+`
+
+const testAccDiskConfigRestoreOnCreate = `
+resource "ucloud_disk" "foo" {
+	availability_zone = "cn-sh2-02"
+	name = "testAccDiskRestoreOnCreate"
+	disk_size = 10
+}
+
+resource "ucloud_disk_snapshot" "foo" {
+	disk_id = "${ucloud_disk.foo.id}"
+	name = "tf-testAccDiskRestoreOnCreate"
+}
+
+resource "ucloud_disk" "bar" {
+	availability_zone = "cn-sh2-02"
+	name = "testAccDiskRestoreOnCreateBar"
+	disk_size = 10
+	restore_from_snapshot_id = "${ucloud_disk_snapshot.foo.id}"
+}
+`
+
+const testAccDiskConfigRestore = `
+resource "ucloud_disk" "foo" {
+	availability_zone = "cn-sh2-02"
+	name = "testAccDiskRestore"
+	disk_size = 10
+}
+
+resource "ucloud_disk_snapshot" "foo" {
+	disk_id = "${ucloud_disk.foo.id}"
+	name = "tf-testAccDiskRestore"
+}
+`
+
+const testAccDiskConfigRestoreUpdate = `
+data "ucloud_disk_snapshots" "foo" {
+	availability_zone = "cn-sh2-02"
+	name_regex = "^tf-testAccDiskRestore$"
+}
+
+resource "ucloud_disk" "foo" {
+	availability_zone = "cn-sh2-02"
+	name = "testAccDiskRestore"
+	disk_size = 10
+	restore_from_snapshot_id = "${data.ucloud_disk_snapshots.foo.snapshots.0.id}"
+}
+
+resource "ucloud_disk_snapshot" "foo" {
+	disk_id = "${ucloud_disk.foo.id}"
+	name = "tf-testAccDiskRestore"
+}
+`
+
+const testAccDiskConfigRestoreAttached = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+data "ucloud_disk_snapshots" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^tf-testAccDiskRestoreAttached$"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccDiskRestoreAttached"
+	instance_type = "n-highcpu-1"
+}
+
+resource "ucloud_disk" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name = "testAccDiskRestoreAttached"
+	disk_size = 10
+	%s
+}
+
+resource "ucloud_disk_attachment" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	disk_id = "${ucloud_disk.foo.id}"
+	instance_id = "${ucloud_instance.foo.id}"
+}
+
+resource "ucloud_disk_snapshot" "foo" {
+	disk_id = "${ucloud_disk_attachment.foo.disk_id}"
+	name = "tf-testAccDiskRestoreAttached"
+}
+`
+
+const testAccDiskConfigRestoreAttachedSnapshot = `restore_from_snapshot_id = "${data.ucloud_disk_snapshots.foo.snapshots.0.id}"`
+
+const testAccDiskConfigResizeAttached = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccDiskResizeAttached"
+	instance_type = "n-highcpu-1"
+}
+
+resource "ucloud_disk" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name = "testAccDiskResizeAttached"
+	disk_size = %d
+	detach_on_resize = true
+}
+
+resource "ucloud_disk_attachment" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	disk_id = "${ucloud_disk.foo.id}"
+	instance_id = "${ucloud_instance.foo.id}"
+}
+`
@@ -19,6 +19,12 @@ func resourceUCloudEIP() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"bandwidth": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -167,7 +173,7 @@ func resourceUCloudEIPCreate(d *schema.ResourceData, meta interface{}) error {
 	d.SetId(eip.EIPId)
 
 	// after create eip, we need to wait it initialized
-	stateConf := eipWaitForState(client, d.Id())
+	stateConf := eipWaitForState(client, d.Id(), d.Timeout(schema.TimeoutCreate))
 
 	_, err = stateConf.WaitForState()
 	if err != nil {
@@ -196,7 +202,7 @@ func resourceUCloudEIPUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		// after update eip bandwidth, we need to wait it completed
-		stateConf := eipWaitForState(client, d.Id())
+		stateConf := eipWaitForState(client, d.Id(), d.Timeout(schema.TimeoutUpdate))
 
 		_, err = stateConf.WaitForState()
 		if err != nil {
@@ -218,7 +224,7 @@ func resourceUCloudEIPUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		// after update eip internet charge mode, we need to wait it completed
-		stateConf := eipWaitForState(client, d.Id())
+		stateConf := eipWaitForState(client, d.Id(), d.Timeout(schema.TimeoutUpdate))
 
 		_, err = stateConf.WaitForState()
 		if err != nil {
@@ -256,7 +262,7 @@ func resourceUCloudEIPUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		// after eip update eip attribute, we need to wait it completed
-		stateConf := eipWaitForState(client, d.Id())
+		stateConf := eipWaitForState(client, d.Id(), d.Timeout(schema.TimeoutUpdate))
 
 		_, err = stateConf.WaitForState()
 		if err != nil {
@@ -278,7 +284,7 @@ func resourceUCloudEIPRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read eip %s, %s", "DescribeEIP", d.Id(), err)
+		return newActionError("DescribeEIP", "eip", d.Id(), err)
 	}
 
 	d.Set("bandwidth", eip.Bandwidth)
@@ -316,9 +322,9 @@ func resourceUCloudEIPDelete(d *schema.ResourceData, meta interface{}) error {
 	req := conn.NewReleaseEIPRequest()
 	req.EIPId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if _, err := conn.ReleaseEIP(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete eip %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("ReleaseEIP", "eip", d.Id(), err))
 		}
 
 		_, err := client.describeEIPById(d.Id())
@@ -327,18 +333,18 @@ func resourceUCloudEIPDelete(d *schema.ResourceData, meta interface{}) error {
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete eip %s, %s", "DescribeEIP", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeEIP", "eip", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete eip but it still exists"))
 	})
 }
 
-func eipWaitForState(client *UCloudClient, eipId string) *resource.StateChangeConf {
+func eipWaitForState(client *UCloudClient, eipId string, timeout time.Duration) *resource.StateChangeConf {
 	return &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"free"},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      2 * time.Second,
 		MinTimeout: 1 * time.Second,
 		Refresh: func() (interface{}, string, error) {
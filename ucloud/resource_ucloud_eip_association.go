@@ -15,6 +15,11 @@ func resourceUCloudEIPAssociation() *schema.Resource {
 		Read:   resourceUCloudEIPAssociationRead,
 		Delete: resourceUCloudEIPAssociationDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"eip_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -61,7 +66,7 @@ func resourceUCloudEIPAssociationCreate(d *schema.ResourceData, meta interface{}
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"used"},
-		Timeout:    5 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutCreate),
 		Delay:      2 * time.Second,
 		MinTimeout: 1 * time.Second,
 		Refresh: func() (interface{}, string, error) {
@@ -101,7 +106,7 @@ func resourceUCloudEIPAssociationRead(d *schema.ResourceData, meta interface{})
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read eip association %s, %s", "DescribeEIP", d.Id(), err)
+		return newActionError("DescribeEIP", "eip association", d.Id(), err)
 	}
 	//TODO:[API-ERROR] UnetEIPResourceSet don't have EIPId
 	d.Set("eip_id", d.Get("eip_id"))
@@ -125,9 +130,9 @@ func resourceUCloudEIPAssociationDelete(d *schema.ResourceData, meta interface{}
 	req.ResourceId = ucloud.String(assoc.ResourceId)
 	req.ResourceType = ucloud.String(assoc.ResourceType)
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if _, err := conn.UnBindEIP(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete eip association %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("UnBindEIP", "eip association", d.Id(), err))
 		}
 
 		_, err := client.describeEIPResourceById(assoc.PrimaryId, assoc.ResourceType, assoc.ResourceId)
@@ -137,7 +142,7 @@ func resourceUCloudEIPAssociationDelete(d *schema.ResourceData, meta interface{}
 				return nil
 			}
 
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete eip association %s, %s", "DescribeEIP", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeEIP", "eip association", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete eip association but it still exists"))
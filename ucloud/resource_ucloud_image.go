@@ -0,0 +1,325 @@
+package ucloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/ucloud/ucloud-sdk-go/ucloud"
+)
+
+func resourceUCloudImage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUCloudImageCreate,
+		Read:   resourceUCloudImageRead,
+		Update: resourceUCloudImageUpdate,
+		Delete: resourceUCloudImageDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: resourceUCloudImageCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateInstanceName,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"availability_zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			// the source of image forces a new image by CustomizeDiff, except it is adopted after import
+			"instance_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"source_image_id", "ufile_url"},
+			},
+
+			"source_image_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"instance_id", "ufile_url"},
+			},
+
+			"source_region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"source_project_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"ufile_url": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"instance_id", "source_image_id"},
+			},
+
+			"format": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringInChoices([]string{"RAW", "VHD", "VMDK", "qcow2"}),
+			},
+
+			"os_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"os_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceUCloudImageCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*UCloudClient)
+	conn := client.uhostconn
+
+	name := d.Get("name").(string)
+	description := d.Get("description").(string)
+
+	if val, ok := d.GetOk("instance_id"); ok {
+		req := conn.NewCreateCustomImageRequest()
+		req.UHostId = ucloud.String(val.(string))
+		req.ImageName = ucloud.String(name)
+		req.ImageDescription = ucloud.String(description)
+		if zone, ok := d.GetOk("availability_zone"); ok {
+			req.Zone = ucloud.String(zone.(string))
+		}
+
+		resp, err := conn.CreateCustomImage(req)
+		if err != nil {
+			return fmt.Errorf("error in create image, %s", err)
+		}
+
+		d.SetId(resp.ImageId)
+	} else if val, ok := d.GetOk("source_image_id"); ok {
+		// the image is copied from the source region and project into the region and project of provider
+		req := conn.NewCopyCustomImageRequest()
+		req.SourceImageId = ucloud.String(val.(string))
+		req.TargetProjectId = ucloud.String(client.projectId)
+		req.TargetImageName = ucloud.String(name)
+		req.TargetImageDescription = ucloud.String(description)
+
+		if region, ok := d.GetOk("source_region"); ok && region.(string) != client.region {
+			req.SetRegion(region.(string))
+			req.TargetRegion = ucloud.String(client.region)
+		}
+
+		if projectId, ok := d.GetOk("source_project_id"); ok {
+			req.SetProjectId(projectId.(string))
+		}
+
+		resp, err := conn.CopyCustomImage(req)
+		if err != nil {
+			return fmt.Errorf("error in copy image, %s", err)
+		}
+
+		d.SetId(resp.TargetImageId)
+	} else if val, ok := d.GetOk("ufile_url"); ok {
+		osType, hasOsType := d.GetOk("os_type")
+		osName, hasOsName := d.GetOk("os_name")
+		format, hasFormat := d.GetOk("format")
+		if !hasOsType || !hasOsName || !hasFormat {
+			return fmt.Errorf("os_type, os_name and format must be set to import image from ufile_url")
+		}
+
+		req := conn.NewImportCustomImageRequest()
+		req.UFileUrl = ucloud.String(val.(string))
+		req.ImageName = ucloud.String(name)
+		req.ImageDescription = ucloud.String(description)
+		req.OsType = ucloud.String(osType.(string))
+		req.OsName = ucloud.String(osName.(string))
+		req.Format = ucloud.String(format.(string))
+		req.Auth = ucloud.Bool(true)
+
+		resp, err := conn.ImportCustomImage(req)
+		if err != nil {
+			return fmt.Errorf("error in import image, %s", err)
+		}
+
+		d.SetId(resp.ImageId)
+	} else {
+		return fmt.Errorf("one of instance_id, source_image_id and ufile_url must be set to create image")
+	}
+
+	// after create image, we need to wait it available
+	stateConf := imageWaitForState(client, d.Id(), d.Timeout(schema.TimeoutCreate))
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("wait for image available failed in create image %s, %s", d.Id(), err)
+	}
+
+	return resourceUCloudImageRead(d, meta)
+}
+
+func resourceUCloudImageUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*UCloudClient)
+
+	if d.HasChange("name") || d.HasChange("description") {
+		if err := client.updateImageAttribute(d.Id(), d.Get("name").(string), d.Get("description").(string)); err != nil {
+			return fmt.Errorf("do %s failed in update image %s, %s", "UpdateImageAttribute", d.Id(), err)
+		}
+	}
+
+	return resourceUCloudImageRead(d, meta)
+}
+
+func resourceUCloudImageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*UCloudClient)
+
+	image, err := client.DescribeImageById(d.Id())
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return newActionError("DescribeImage", "image", d.Id(), err)
+	}
+
+	d.Set("name", image.ImageName)
+	d.Set("description", image.ImageDescription)
+	d.Set("availability_zone", image.Zone)
+	d.Set("os_type", image.OsType)
+	d.Set("os_name", image.OsName)
+	d.Set("type", image.ImageType)
+	d.Set("size", image.ImageSize)
+	d.Set("status", image.State)
+	d.Set("create_time", timestampToString(image.CreateTime))
+
+	return nil
+}
+
+func resourceUCloudImageDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*UCloudClient)
+	conn := client.uhostconn
+
+	req := conn.NewTerminateCustomImageRequest()
+	req.ImageId = ucloud.String(d.Id())
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		if _, err := conn.TerminateCustomImage(req); err != nil {
+			if isResourceInUseError(err) {
+				return resource.RetryableError(newActionError("TerminateCustomImage", "image", d.Id(), err))
+			}
+			return resource.NonRetryableError(newActionError("TerminateCustomImage", "image", d.Id(), err))
+		}
+
+		if _, err := client.DescribeImageById(d.Id()); err != nil {
+			if isNotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(newActionError("DescribeImage", "image", d.Id(), err))
+		}
+
+		return resource.RetryableError(fmt.Errorf("delete image but it still exists"))
+	})
+}
+
+// imageSourceKeys is the arguments about the source of image, changing any of them forces a new image
+var imageSourceKeys = []string{"instance_id", "source_image_id", "source_region", "source_project_id", "ufile_url", "format"}
+
+// isImageSourceUnknown will check whether none of instance_id, source_image_id and ufile_url is in state,
+// it only happens before the first apply after import, because the source is not returned by api.
+// In this case, the source is adopted from config on the first apply, and the later changes force a new image as usual.
+func isImageSourceUnknown(getChange func(string) (interface{}, interface{})) bool {
+	for _, key := range []string{"instance_id", "source_image_id", "ufile_url"} {
+		if old, _ := getChange(key); old.(string) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceUCloudImageCustomizeDiff will force a new image to be created if the source of image is changed,
+// unless the source is adopted after import.
+func resourceUCloudImageCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || isImageSourceUnknown(diff.GetChange) {
+		return nil
+	}
+
+	for _, key := range imageSourceKeys {
+		if diff.HasChange(key) {
+			if err := diff.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func imageWaitForState(client *UCloudClient, imageId string, timeout time.Duration) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     []string{"Available"},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			image, err := client.DescribeImageById(imageId)
+			if err != nil {
+				if isNotFoundError(err) {
+					return nil, "pending", nil
+				}
+				return nil, "", err
+			}
+
+			state := image.State
+			if state == "Unavailable" {
+				return nil, "", fmt.Errorf("image is unavailable, please check the source of image")
+			}
+
+			if state != "Available" {
+				state = "pending"
+			}
+
+			return image, state, nil
+		},
+	}
+}
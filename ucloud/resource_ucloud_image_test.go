@@ -0,0 +1,309 @@
+package ucloud
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/ucloud/ucloud-sdk-go/services/uhost"
+)
+
+func TestAccUCloudImage_basic(t *testing.T) {
+	var image uhost.UHostImageSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_image.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckImageDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccImageConfig, "tf-testAccImageConfig", "golden image"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImageExists("ucloud_image.foo", &image),
+					resource.TestCheckResourceAttr("ucloud_image.foo", "name", "tf-testAccImageConfig"),
+					resource.TestCheckResourceAttr("ucloud_image.foo", "description", "golden image"),
+					resource.TestCheckResourceAttr("ucloud_image.foo", "type", "Custom"),
+					resource.TestCheckResourceAttr("ucloud_image.foo", "status", "Available"),
+					resource.TestCheckResourceAttrPair("ucloud_image.foo", "availability_zone", "ucloud_instance.foo", "availability_zone"),
+					resource.TestCheckResourceAttrPair("ucloud_image.foo", "os_name", "data.ucloud_images.default", "images.0.os_name"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccImageConfig, "tf-testAccImageConfigUpdate", "golden image v2"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImageExists("ucloud_image.foo", &image),
+					resource.TestCheckResourceAttr("ucloud_image.foo", "name", "tf-testAccImageConfigUpdate"),
+					resource.TestCheckResourceAttr("ucloud_image.foo", "description", "golden image v2"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "ucloud_image.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"instance_id"},
+			},
+		},
+	})
+}
+
+func TestAccUCloudImage_copy(t *testing.T) {
+	var image uhost.UHostImageSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_image.bar",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckImageDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccImageConfigCopy,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImageExists("ucloud_image.bar", &image),
+					resource.TestCheckResourceAttr("ucloud_image.bar", "name", "tf-testAccImageConfigCopy"),
+					resource.TestCheckResourceAttr("ucloud_image.bar", "type", "Custom"),
+					resource.TestCheckResourceAttr("ucloud_image.bar", "status", "Available"),
+					resource.TestCheckResourceAttrPair("ucloud_image.bar", "os_name", "ucloud_image.foo", "os_name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudImage_import(t *testing.T) {
+	var image uhost.UHostImageSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_image.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckImageDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccImageConfigImport,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImageExists("ucloud_image.foo", &image),
+					resource.TestCheckResourceAttr("ucloud_image.foo", "os_type", "CentOS"),
+					resource.TestCheckResourceAttr("ucloud_image.foo", "os_name", "CentOS 7.4 64位"),
+					resource.TestCheckResourceAttr("ucloud_image.foo", "status", "Available"),
+				),
+			},
+		},
+	})
+}
+
+// Test_resourceUCloudImage_sourceDiff checks the source of imported image is adopted on the first apply,
+// and the later changes of the source still force a new image.
+func Test_resourceUCloudImage_sourceDiff(t *testing.T) {
+	tests := []struct {
+		name            string
+		state           map[string]string
+		config          map[string]interface{}
+		key             string
+		wantNew         string
+		wantRequiresNew bool
+	}{
+		{
+			"adopt instance_id after import",
+			map[string]string{},
+			map[string]interface{}{"instance_id": "uhost-foo"},
+			"instance_id",
+			"uhost-foo",
+			false,
+		},
+		{
+			"adopt source_region after import",
+			map[string]string{},
+			map[string]interface{}{"source_image_id": "uimage-foo", "source_region": "cn-bj2"},
+			"source_region",
+			"cn-bj2",
+			false,
+		},
+		{
+			"change instance_id after import",
+			map[string]string{"instance_id": "uhost-foo"},
+			map[string]interface{}{"instance_id": "uhost-bar"},
+			"instance_id",
+			"uhost-bar",
+			true,
+		},
+		{
+			"set source_region after import",
+			map[string]string{"source_image_id": "uimage-foo"},
+			map[string]interface{}{"source_image_id": "uimage-foo", "source_region": "cn-bj2"},
+			"source_region",
+			"cn-bj2",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := map[string]string{"name": "tf-test-image"}
+			raw := map[string]interface{}{"name": "tf-test-image"}
+			for k, v := range tt.state {
+				attributes[k] = v
+			}
+			for k, v := range tt.config {
+				raw[k] = v
+			}
+
+			c, err := config.NewRawConfig(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			state := &terraform.InstanceState{ID: "uimage-foo", Attributes: attributes}
+			diff, err := resourceUCloudImage().Diff(state, terraform.NewResourceConfig(c), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff == nil || diff.Attributes[tt.key] == nil {
+				t.Fatalf("expected a diff of %s, got none", tt.key)
+			}
+
+			attr := diff.Attributes[tt.key]
+			if attr.New != tt.wantNew {
+				t.Errorf("expected new %s %q, got %q", tt.key, tt.wantNew, attr.New)
+			}
+			if attr.RequiresNew != tt.wantRequiresNew {
+				t.Errorf("expected %s requires new %v, got %v", tt.key, tt.wantRequiresNew, attr.RequiresNew)
+			}
+		})
+	}
+}
+
+func testAccCheckImageExists(n string, image *uhost.UHostImageSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("image id is empty")
+		}
+
+		client := testAccProvider.Meta().(*UCloudClient)
+		ptr, err := client.DescribeImageById(rs.Primary.ID)
+
+		log.Printf("[INFO] image id %#v", rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		*image = *ptr
+		return nil
+	}
+}
+
+func testAccCheckImageDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ucloud_image" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*UCloudClient)
+		image, err := client.DescribeImageById(rs.Primary.ID)
+
+		// Verify the error is what we want
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+
+		if image.ImageId != "" {
+			return fmt.Errorf("image still exist")
+		}
+	}
+
+	return nil
+}
+
+const testAccImageConfig = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccImageConfig"
+	instance_type = "n-highcpu-1"
+}
+
+resource "ucloud_image" "foo" {
+	instance_id = "${ucloud_instance.foo.id}"
+	name = "%s"
+	description = "%s"
+}
+`
+
+const testAccImageConfigCopy = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccImageConfigCopy"
+	instance_type = "n-highcpu-1"
+}
+
+resource "ucloud_image" "foo" {
+	instance_id = "${ucloud_instance.foo.id}"
+	name = "tf-testAccImageConfigSource"
+}
+
+resource "ucloud_image" "bar" {
+	source_image_id = "${ucloud_image.foo.id}"
+	name = "tf-testAccImageConfigCopy"
+}
+`
+
+const testAccImageConfigImport = `
+resource "ucloud_image" "foo" {
+	ufile_url = "http://tf-acc.cn-sh2.ufileos.com/centos74.qcow2"
+	name = "tf-testAccImageConfigImport"
+	os_type = "CentOS"
+	os_name = "CentOS 7.4 64位"
+	format = "qcow2"
+}
+`
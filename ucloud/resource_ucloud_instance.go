@@ -1,7 +1,9 @@
 package ucloud
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -21,10 +23,12 @@ func resourceUCloudInstance() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceUCloudInstanceCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
-			Delete: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -39,17 +43,89 @@ func resourceUCloudInstance() *schema.Resource {
 				Required: true,
 			},
 
+			"running": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"reboot_trigger": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"keep_data_disks_on_reinstall": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
 			"root_password": &schema.Schema{
-				Type:         schema.TypeString,
-				Required:     true,
-				Sensitive:    true,
-				ValidateFunc: validateInstancePassword,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ValidateFunc:  validateInstancePassword,
+				ConflictsWith: []string{"key_pair_id"},
+			},
+
+			// key_pair_id forces a new instance by CustomizeDiff, except it is adopted after import
+			"key_pair_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"root_password"},
+			},
+
+			"user_data": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"user_data_base64"},
+				ValidateFunc:     validateUserData,
+				DiffSuppressFunc: suppressUserDataDiff,
+				StateFunc: func(v interface{}) string {
+					return userDataHashSum(v.(string))
+				},
+			},
+
+			"user_data_base64": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"user_data"},
+				ValidateFunc:     validateUserDataBase64,
+				DiffSuppressFunc: suppressUserDataDiff,
+				StateFunc: func(v interface{}) string {
+					return userDataHashSum(v.(string))
+				},
+			},
+
+			"user_data_replace_on_change": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
 			},
 
 			"instance_type": &schema.Schema{
 				Type:         schema.TypeString,
 				Required:     true,
 				ValidateFunc: validateInstanceType,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					oldType, err := parseInstanceType(old)
+					if err != nil {
+						return false
+					}
+
+					newType, err := parseInstanceType(new)
+					return err == nil && oldType.Equal(newType)
+				},
+			},
+
+			"net_capability": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringInChoices([]string{"Normal", "Super"}),
 			},
 
 			"name": &schema.Schema{
@@ -102,6 +178,48 @@ func resourceUCloudInstance() *schema.Resource {
 				ValidateFunc: validateStringInChoices([]string{"LOCAL_NORMAL", "LOCAL_SSD"}),
 			},
 
+			"data_disks": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"data_disk_size", "data_disk_type"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateStringInChoices([]string{"LOCAL_NORMAL", "LOCAL_SSD", "CLOUD_NORMAL", "CLOUD_SSD"}),
+						},
+
+						"size": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validateDataDiskSize(20, 8000),
+						},
+
+						"backup_type": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "NONE",
+							ValidateFunc: validateStringInChoices([]string{"NONE", "DATAARK"}),
+						},
+
+						"disk_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"data_protection": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"remark": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -133,6 +251,27 @@ func resourceUCloudInstance() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"private_ip": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIPv4Address,
+			},
+
+			"private_mac": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateMACAddress,
+			},
+
+			"public_ip": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"cpu": &schema.Schema{
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -143,6 +282,11 @@ func resourceUCloudInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"gpu": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
 			"status": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -216,11 +360,19 @@ func resourceUCloudInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 	client := meta.(*UCloudClient)
 	conn := client.uhostconn
 
+	password, hasPassword := d.GetOk("root_password")
+	keyPairId, hasKeyPair := d.GetOk("key_pair_id")
+	if !hasPassword && !hasKeyPair {
+		return fmt.Errorf("one of root_password and key_pair_id must be set to login the instance")
+	}
+
 	req := conn.NewCreateUHostInstanceRequest()
-	req.LoginMode = ucloud.String("Password")
 	req.Zone = ucloud.String(d.Get("availability_zone").(string))
 	req.ImageId = ucloud.String(d.Get("image_id").(string))
-	req.Password = ucloud.String(d.Get("root_password").(string))
+	if hasPassword {
+		req.LoginMode = ucloud.String("Password")
+		req.Password = ucloud.String(password.(string))
+	}
 	req.ChargeType = ucloud.String(d.Get("instance_charge_type").(string))
 	req.Quantity = ucloud.Int(d.Get("instance_duration").(int))
 	req.Name = ucloud.String(d.Get("name").(string))
@@ -229,6 +381,17 @@ func resourceUCloudInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 	t, _ := parseInstanceType(d.Get("instance_type").(string))
 	req.CPU = ucloud.Int(t.CPU)
 	req.Memory = ucloud.Int(t.Memory)
+	if uhostType := t.UHostType(); uhostType != "" {
+		req.UHostType = ucloud.String(uhostType)
+	}
+
+	if t.GPU > 0 {
+		req.GPU = ucloud.Int(t.GPU)
+	}
+
+	if val, ok := d.GetOk("net_capability"); ok {
+		req.NetCapability = ucloud.String(val.(string))
+	}
 
 	imageResp, err := client.DescribeImageById(d.Get("image_id").(string))
 	if err != nil {
@@ -251,10 +414,46 @@ func resourceUCloudInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 		req.Disks = append(req.Disks, dataDisk)
 	}
 
+	if val, ok := d.GetOk("data_disks"); ok {
+		localDiskCount := 0
+		for _, item := range val.([]interface{}) {
+			disk := item.(map[string]interface{})
+			dataDisk := uhost.UHostDisk{}
+			dataDisk.IsBoot = ucloud.String("False")
+			dataDisk.Type = ucloud.String(disk["type"].(string))
+			dataDisk.Size = ucloud.Int(disk["size"].(int))
+			dataDisk.BackupType = ucloud.String(disk["backup_type"].(string))
+
+			if isLocalDiskType(disk["type"].(string)) {
+				localDiskCount++
+			}
+
+			req.Disks = append(req.Disks, dataDisk)
+		}
+
+		if localDiskCount > 1 {
+			return fmt.Errorf("only one local data disk is supported, got %d in data_disks", localDiskCount)
+		}
+	}
+
 	if val, ok := d.GetOk("tag"); ok {
 		req.Tag = ucloud.String(val.(string))
 	}
 
+	// the data ark is only supported by the combination of local disks or cloud disks, it is checked by api
+	if d.Get("data_protection").(bool) {
+		req.TimemachineFeature = ucloud.String("Yes")
+	}
+
+	// the user data is run by cloud-init when the instance is started at the first time
+	if val, ok := d.GetOk("user_data"); ok {
+		req.UserDataScript = ucloud.String(base64.StdEncoding.EncodeToString([]byte(val.(string))))
+	}
+
+	if val, ok := d.GetOk("user_data_base64"); ok {
+		req.UserDataScript = ucloud.String(val.(string))
+	}
+
 	if val, ok := d.GetOk("vpc_id"); ok {
 		req.VPCId = ucloud.String(val.(string))
 	}
@@ -263,6 +462,34 @@ func resourceUCloudInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 		req.SubnetId = ucloud.String(val.(string))
 	}
 
+	// the private ip must be in the cidr block of subnet
+	if val, ok := d.GetOk("private_ip"); ok {
+		subnetId, ok := d.GetOk("subnet_id")
+		if !ok {
+			return fmt.Errorf("subnet_id must be set if private_ip is set in create instance")
+		}
+
+		subnet, err := client.describeSubnetById(subnetId.(string))
+		if err != nil {
+			return fmt.Errorf("do %s failed in create instance, %s", "DescribeSubnet", err)
+		}
+
+		_, ipNet, err := net.ParseCIDR(subnet.Subnet + "/" + subnet.Netmask)
+		if err != nil {
+			return fmt.Errorf("error in parse cidr block of subnet %s, %s", subnet.SubnetId, err)
+		}
+
+		if !ipNet.Contains(net.ParseIP(val.(string))) {
+			return fmt.Errorf("private_ip %s is invalid, should be in the cidr block %s of subnet %s", val.(string), ipNet.String(), subnet.SubnetId)
+		}
+
+		req.PrivateIp = []string{val.(string)}
+	}
+
+	if val, ok := d.GetOk("private_mac"); ok {
+		req.PrivateMac = ucloud.String(val.(string))
+	}
+
 	if val, ok := d.GetOk("security_group"); ok {
 		resp, err := client.describeFirewallById(val.(string))
 		if err != nil {
@@ -272,7 +499,12 @@ func resourceUCloudInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 		req.SecurityGroupId = ucloud.String(resp.GroupId)
 	}
 
-	resp, err := conn.CreateUHostInstance(req)
+	var resp *uhost.CreateUHostInstanceResponse
+	if hasKeyPair {
+		resp, err = client.createInstanceWithKeyPair(req, keyPairId.(string))
+	} else {
+		resp, err = conn.CreateUHostInstance(req)
+	}
 	if err != nil {
 		return fmt.Errorf("error in create instance, %s", err)
 	}
@@ -400,6 +632,36 @@ func resourceUCloudInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 		resizeNeedUpdate = true
 	}
 
+	// the local data disk is resized with instance, and the cloud data disks are resized separately
+	cloudDiskSizes := map[string]int{}
+	if d.HasChange("data_disks") && !d.IsNewResource() {
+		d.SetPartial("data_disks")
+		o, n := d.GetChange("data_disks")
+		oldDisks := o.([]interface{})
+		for i, item := range n.([]interface{}) {
+			if i >= len(oldDisks) {
+				break
+			}
+
+			oldDisk, newDisk := oldDisks[i].(map[string]interface{}), item.(map[string]interface{})
+			oldSize, newSize := oldDisk["size"].(int), newDisk["size"].(int)
+			if oldSize == newSize {
+				continue
+			}
+
+			if oldSize > newSize {
+				return fmt.Errorf("reduce data disk size is not supported, new value %d of data_disks.%d should be larger than the old value %d", newSize, i, oldSize)
+			}
+
+			if isLocalDiskType(oldDisk["type"].(string)) {
+				resizeReq.DiskSpace = ucloud.Int(newSize)
+				resizeNeedUpdate = true
+			} else {
+				cloudDiskSizes[oldDisk["disk_id"].(string)] = newSize
+			}
+		}
+	}
+
 	if d.HasChange("boot_disk_size") && !d.IsNewResource() {
 		d.SetPartial("boot_disk_size")
 		oldSize, newSize := d.GetChange("boot_disk_size")
@@ -410,8 +672,29 @@ func resourceUCloudInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 		resizeNeedUpdate = true
 	}
 
+	// the instance is reinstalled with the new image in place, so that its ips and eip bindings are kept
+	imageNeedUpdate := false
+	if d.HasChange("image_id") && !d.IsNewResource() {
+		d.SetPartial("image_id")
+		imageNeedUpdate = true
+	}
+
+	// the data protection can only be upgraded to enabled, it is checked by CustomizeDiff
+	arkNeedUpdate := false
+	if d.HasChange("data_protection") && d.Get("data_protection").(bool) && !d.IsNewResource() {
+		d.SetPartial("data_protection")
+		arkNeedUpdate = true
+	}
+
+	// the login of imported instance is adopted from config without reset, see isInstanceLoginUnknown
+	loginUnknown := isInstanceLoginUnknown(d.GetChange) && !d.IsNewResource()
+	if loginUnknown {
+		d.SetPartial("root_password")
+		d.SetPartial("key_pair_id")
+	}
+
 	passwordNeedUpdate := false
-	if d.HasChange("root_password") && !d.IsNewResource() {
+	if d.HasChange("root_password") && d.Get("root_password").(string) != "" && !d.IsNewResource() && !loginUnknown {
 		instance, err := client.describeInstanceById(d.Id())
 
 		if err != nil {
@@ -430,7 +713,7 @@ func resourceUCloudInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 		}
 	}
 
-	if passwordNeedUpdate || resizeNeedUpdate {
+	if imageNeedUpdate || passwordNeedUpdate || resizeNeedUpdate || arkNeedUpdate || len(cloudDiskSizes) > 0 {
 		// instance update these attributes need to wait it stopped
 		stopReq := conn.NewStopUHostInstanceRequest()
 		stopReq.UHostId = ucloud.String(d.Id())
@@ -467,7 +750,8 @@ func resourceUCloudInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 			}
 		}
 
-		if passwordNeedUpdate {
+		// the password is reset by reinstall if the image is changed
+		if passwordNeedUpdate && !imageNeedUpdate {
 			reqPassword := conn.NewResetUHostInstancePasswordRequest()
 			reqPassword.UHostId = ucloud.String(d.Id())
 			reqPassword.Password = ucloud.String(d.Get("root_password").(string))
@@ -487,6 +771,27 @@ func resourceUCloudInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 			}
 		}
 
+		for diskId, size := range cloudDiskSizes {
+			diskReq := client.udiskconn.NewResizeUDiskRequest()
+			diskReq.Zone = ucloud.String(d.Get("availability_zone").(string))
+			diskReq.UDiskId = ucloud.String(diskId)
+			diskReq.Size = ucloud.Int(size)
+
+			if _, err := client.udiskconn.ResizeUDisk(diskReq); err != nil {
+				return fmt.Errorf("do %s failed in update instance %s, %s", "ResizeUDisk", d.Id(), err)
+			}
+		}
+
+		if arkNeedUpdate {
+			arkReq := conn.NewUpgradeToArkUHostInstanceRequest()
+			arkReq.Zone = ucloud.String(d.Get("availability_zone").(string))
+			arkReq.UHostIds = []string{d.Id()}
+
+			if _, err := conn.UpgradeToArkUHostInstance(arkReq); err != nil {
+				return fmt.Errorf("do %s failed in update instance %s, %s", "UpgradeToArkUHostInstance", d.Id(), err)
+			}
+		}
+
 		// instance stopped means instance update complete
 		stateConf := &resource.StateChangeConf{
 			Pending:    []string{"pending"},
@@ -501,15 +806,79 @@ func resourceUCloudInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 			return fmt.Errorf("wait for instance update failed in update instance %s, %s", d.Id(), err)
 		}
 
-		// after instance update, we need to wait it started
-		startReq := conn.NewStartUHostInstanceRequest()
-		startReq.UHostId = ucloud.String(d.Id())
+		// the data ark is enabled asynchronously after the instance is upgraded
+		if arkNeedUpdate {
+			stateConf = &resource.StateChangeConf{
+				Pending:    []string{"pending"},
+				Target:     []string{"yes"},
+				Refresh:    instanceDataProtectionRefreshFunc(client, d.Id()),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+				Delay:      5 * time.Second,
+				MinTimeout: 3 * time.Second,
+			}
+
+			if _, err = stateConf.WaitForState(); err != nil {
+				return fmt.Errorf("wait for instance upgrade to data ark failed in update instance %s, %s", d.Id(), err)
+			}
+		}
+
+		// the instance is started after reinstalled, otherwise we need to start it
+		if imageNeedUpdate {
+			reinstallReq := conn.NewReinstallUHostInstanceRequest()
+			reinstallReq.UHostId = ucloud.String(d.Id())
+			reinstallReq.ImageId = ucloud.String(d.Get("image_id").(string))
+			reinstallReq.ReserveDisk = ucloud.String("Yes")
+			if !d.Get("keep_data_disks_on_reinstall").(bool) {
+				reinstallReq.ReserveDisk = ucloud.String("No")
+			}
+
+			if password, ok := d.GetOk("root_password"); ok {
+				reinstallReq.Password = ucloud.String(password.(string))
+				_, err = conn.ReinstallUHostInstance(reinstallReq)
+			} else {
+				// the password must not be set if the instance is logged in by key pair
+				var resp uhost.ReinstallUHostInstanceResponse
+				err = client.uhostgenericconn.InvokeAction("ReinstallUHostInstance", reinstallReq, &resp)
+			}
+
+			if err != nil {
+				return fmt.Errorf("do %s failed in update instance %s, %s", "ReinstallUHostInstance", d.Id(), err)
+			}
+		} else if d.Get("running").(bool) {
+			// after instance update, we need to wait it started
+			startReq := conn.NewStartUHostInstanceRequest()
+			startReq.UHostId = ucloud.String(d.Id())
 
-		if _, err := conn.StartUHostInstance(startReq); err != nil {
-			return fmt.Errorf("do %s failed in update instance %s, %s", "StartUHostInstance", d.Id(), err)
+			if _, err := conn.StartUHostInstance(startReq); err != nil {
+				return fmt.Errorf("do %s failed in update instance %s, %s", "StartUHostInstance", d.Id(), err)
+			}
 		}
 
-		stateConf = &resource.StateChangeConf{
+		if imageNeedUpdate || d.Get("running").(bool) {
+			stateConf = &resource.StateChangeConf{
+				Pending:    []string{"pending"},
+				Target:     []string{"running"},
+				Refresh:    instanceStateRefreshFunc(client, d.Id(), "running"),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+				Delay:      5 * time.Second,
+				MinTimeout: 3 * time.Second,
+			}
+
+			if _, err = stateConf.WaitForState(); err != nil {
+				return fmt.Errorf("wait for instance start failed in update instance %s, %s", d.Id(), err)
+			}
+		}
+	} else if d.HasChange("reboot_trigger") && !d.IsNewResource() && d.Get("running").(bool) {
+		// the instance is rebooted if the trigger is changed, it is skipped if the instance is restarted above
+		d.SetPartial("reboot_trigger")
+		rebootReq := conn.NewRebootUHostInstanceRequest()
+		rebootReq.UHostId = ucloud.String(d.Id())
+
+		if _, err := conn.RebootUHostInstance(rebootReq); err != nil {
+			return fmt.Errorf("do %s failed in update instance %s, %s", "RebootUHostInstance", d.Id(), err)
+		}
+
+		stateConf := &resource.StateChangeConf{
 			Pending:    []string{"pending"},
 			Target:     []string{"running"},
 			Refresh:    instanceStateRefreshFunc(client, d.Id(), "running"),
@@ -518,11 +887,16 @@ func resourceUCloudInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 			MinTimeout: 3 * time.Second,
 		}
 
-		if _, err = stateConf.WaitForState(); err != nil {
-			return fmt.Errorf("wait for instance start failed in update instance %s, %s", d.Id(), err)
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("wait for instance reboot failed in update instance %s, %s", d.Id(), err)
 		}
 	}
 
+	// converge the power state of instance to the desired one
+	if err := instancePowerStateConverge(client, d.Id(), d.Get("running").(bool), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("error in update instance %s, %s", d.Id(), err)
+	}
+
 	d.Partial(false)
 
 	return resourceUCloudInstanceRead(d, meta)
@@ -538,35 +912,84 @@ func resourceUCloudInstanceRead(d *schema.ResourceData, meta interface{}) error
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read instance %s, %s", "DescribeUHostInstance", d.Id(), err)
+		return newActionError("DescribeUHostInstance", "instance", d.Id(), err)
 	}
 
-	// TODO: [API-ERROR] image id is different between twice request
-	d.Set("image_id", d.Get("image_id").(string))
+	imageId, err := reconcileInstanceImageId(client, d.Get("image_id").(string), instance)
+	if err != nil {
+		return newActionError("DescribeImage", "instance", d.Id(), err)
+	}
+	d.Set("image_id", imageId)
 
 	d.Set("name", instance.Name)
 	d.Set("instance_charge_type", instance.ChargeType)
 	d.Set("availability_zone", instance.Zone)
-	d.Set("instance_type", d.Get("instance_type").(string))
+
+	// the instance type in state is kept if it is the same machine, such as "n-customized-1-2" and "n-basic-1"
+	instanceType := newInstanceTypeByUHost(instance.CPU, instance.Memory, instance.UHostType, instance.GPU)
+	if oldType, err := parseInstanceType(d.Get("instance_type").(string)); err != nil || !oldType.Equal(instanceType) {
+		d.Set("instance_type", instanceType.String())
+	}
+
+	// the password and key pair are not returned by api, they are kept in state
 	d.Set("root_password", d.Get("root_password").(string))
-	d.Set("security_group", d.Get("security_group").(string))
+	d.Set("key_pair_id", d.Get("key_pair_id").(string))
+
+	sg, err := client.describeFirewallByResource("UHost", d.Id())
+	if err != nil && !isNotFoundError(err) {
+		return newActionError("DescribeFirewall", "instance", d.Id(), err)
+	}
+
+	if sg != nil {
+		d.Set("security_group", sg.FWId)
+	} else {
+		d.Set("security_group", "")
+	}
 	d.Set("tag", instance.Tag)
 	d.Set("cpu", instance.CPU)
 	d.Set("memory", instance.Memory)
-	d.Set("state", instance.State)
+	d.Set("gpu", instance.GPU)
+	d.Set("net_capability", instance.NetCapability)
+	d.Set("data_protection", strings.ToLower(instance.TimemachineFeature) == "yes")
+	d.Set("status", instance.State)
+	d.Set("running", instance.State != "Stopped")
 	d.Set("create_time", timestampToString(instance.CreateTime))
 	d.Set("expire_time", timestampToString(instance.ExpireTime))
 	d.Set("auto_renew", instance.AutoRenew)
 	d.Set("remark", instance.Remark)
 
 	ipSet := []map[string]interface{}{}
+	privateIP, publicIP := "", ""
 	for _, item := range instance.IPSet {
 		ipSet = append(ipSet, map[string]interface{}{
 			"ip":   item.IP,
 			"type": item.Type,
 		})
+
+		if item.Type == "Private" {
+			if privateIP == "" {
+				privateIP = item.IP
+			}
+		} else if publicIP == "" {
+			publicIP = item.IP
+		}
 	}
 	d.Set("ip_set", ipSet)
+	d.Set("private_ip", privateIP)
+	d.Set("public_ip", publicIP)
+
+	// the mac address is not in the response of sdk, it is described by the generic connection
+	privateMac, err := client.describeInstancePrivateMac(d.Id())
+	if err != nil {
+		return newActionError("DescribeUHostInstance", "instance", d.Id(), err)
+	}
+	d.Set("private_mac", privateMac)
+
+	// the data disk type is meaningless without local data disk, it is kept as default in this case
+	dataDiskType := d.Get("data_disk_type").(string)
+	if dataDiskType == "" {
+		dataDiskType = "LOCAL_NORMAL"
+	}
 
 	diskSet := []map[string]interface{}{}
 	for _, item := range instance.DiskSet {
@@ -579,14 +1002,22 @@ func resourceUCloudInstanceRead(d *schema.ResourceData, meta interface{}) error
 
 		if item.IsBoot == "True" {
 			d.Set("boot_disk_size", item.Size)
+			d.Set("boot_disk_type", item.DiskType)
 		}
 
 		if item.IsBoot == "False" && checkStringIn(item.DiskType, []string{"LOCAL_NORMAL", "LOCAL_SSD"}) == nil {
 			d.Set("data_disk_size", item.Size)
+			// the local data disk of data_disks is described by its own type
+			if len(d.Get("data_disks").([]interface{})) == 0 {
+				dataDiskType = item.DiskType
+			}
 		}
 	}
 
+	d.Set("data_disk_type", dataDiskType)
+
 	d.Set("disk_set", diskSet)
+	d.Set("data_disks", flattenInstanceDataDisks(d.Get("data_disks").([]interface{}), instance.DiskSet))
 
 	return nil
 }
@@ -601,7 +1032,7 @@ func resourceUCloudInstanceDelete(d *schema.ResourceData, meta interface{}) erro
 	deleReq := conn.NewTerminateUHostInstanceRequest()
 	deleReq.UHostId = ucloud.String(d.Id())
 
-	return resource.Retry(15*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		instance, err := client.describeInstanceById(d.Id())
 		if err != nil {
 			if isNotFoundError(err) {
@@ -612,7 +1043,7 @@ func resourceUCloudInstanceDelete(d *schema.ResourceData, meta interface{}) erro
 
 		if instance.State != "Stopped" {
 			if _, err := conn.StopUHostInstance(stopReq); err != nil {
-				return resource.RetryableError(fmt.Errorf("do %s failed in delete instance %s, %s", "StopUHostInstance", d.Id(), err))
+				return resource.RetryableError(newActionError("StopUHostInstance", "instance", d.Id(), err))
 			}
 
 			stateConf := &resource.StateChangeConf{
@@ -630,7 +1061,7 @@ func resourceUCloudInstanceDelete(d *schema.ResourceData, meta interface{}) erro
 		}
 
 		if _, err := conn.TerminateUHostInstance(deleReq); err != nil {
-			return resource.RetryableError(fmt.Errorf("error in delete instance %s, %s", d.Id(), err))
+			return resource.RetryableError(newActionError("TerminateUHostInstance", "instance", d.Id(), err))
 		}
 
 		if _, err := client.describeInstanceById(d.Id()); err != nil {
@@ -638,13 +1069,197 @@ func resourceUCloudInstanceDelete(d *schema.ResourceData, meta interface{}) erro
 				return nil
 			}
 
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete instance %s, %s", "DescribeUHostInstance", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeUHostInstance", "instance", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete instance but it still exists"))
 	})
 }
 
+// isInstanceLoginUnknown will check whether neither root_password nor key_pair_id is in state,
+// it only happens before the first apply after import, because they are not returned by api.
+// In this case, they are adopted from config on the first apply, and the later changes take effect as usual.
+func isInstanceLoginUnknown(getChange func(string) (interface{}, interface{})) bool {
+	oldPassword, _ := getChange("root_password")
+	oldKeyPairId, _ := getChange("key_pair_id")
+	return oldPassword.(string) == "" && oldKeyPairId.(string) == ""
+}
+
+// resourceUCloudInstanceCustomizeDiff will force a new instance to be created if the machine family or the count of gpu is changed,
+// because they cannot be resized in place, and it will reject to disable the data protection which cannot be downgraded.
+// The change of key_pair_id also forces a new instance, unless it is adopted after import.
+func resourceUCloudInstanceCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	if diff.HasChange("key_pair_id") && !isInstanceLoginUnknown(diff.GetChange) {
+		if err := diff.ForceNew("key_pair_id"); err != nil {
+			return err
+		}
+	}
+
+	if o, n := diff.GetChange("data_protection"); o.(bool) && !n.(bool) {
+		return fmt.Errorf("data_protection cannot be disabled for instance %s, please create a new instance instead", diff.Id())
+	}
+
+	if !diff.HasChange("instance_type") || !diff.NewValueKnown("instance_type") {
+		return nil
+	}
+
+	o, n := diff.GetChange("instance_type")
+	oldType, err := parseInstanceType(o.(string))
+	if err != nil {
+		return nil
+	}
+
+	newType, err := parseInstanceType(n.(string))
+	if err != nil {
+		return err
+	}
+
+	if oldType.HostType != newType.HostType || oldType.GPU != newType.GPU {
+		return diff.ForceNew("instance_type")
+	}
+
+	return nil
+}
+
+// instancePowerStateConverge will start or stop the instance and wait it done if the power state is not the desired one
+func instancePowerStateConverge(client *UCloudClient, instanceId string, running bool, timeout time.Duration) error {
+	conn := client.uhostconn
+
+	instance, err := client.describeInstanceById(instanceId)
+	if err != nil {
+		return newActionError("DescribeUHostInstance", "instance", instanceId, err)
+	}
+
+	target := "running"
+	if running && instance.State == "Stopped" {
+		req := conn.NewStartUHostInstanceRequest()
+		req.UHostId = ucloud.String(instanceId)
+
+		if _, err := conn.StartUHostInstance(req); err != nil {
+			return newActionError("StartUHostInstance", "instance", instanceId, err)
+		}
+	} else if !running && instance.State != "Stopped" {
+		target = "stopped"
+		req := conn.NewStopUHostInstanceRequest()
+		req.UHostId = ucloud.String(instanceId)
+
+		if _, err := conn.StopUHostInstance(req); err != nil {
+			return newActionError("StopUHostInstance", "instance", instanceId, err)
+		}
+	} else {
+		return nil
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     []string{target},
+		Refresh:    instanceStateRefreshFunc(client, instanceId, target),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("wait for instance %s to be %s failed, %s", instanceId, target, err)
+	}
+
+	return nil
+}
+
+// reconcileInstanceImageId will return the image id of instance, the ImageId of instance is the id of boot disk actually,
+// and the BasicImageId is the source base image of custom image, so that the custom image in state is kept if it still exists.
+func reconcileInstanceImageId(client *UCloudClient, imageId string, instance *uhost.UHostInstanceSet) (string, error) {
+	if instance.BasicImageId == "" || instance.BasicImageId == imageId {
+		return imageId, nil
+	}
+
+	if imageId == "" {
+		return instance.BasicImageId, nil
+	}
+
+	image, err := client.DescribeImageById(imageId)
+	if err != nil {
+		if isNotFoundError(err) {
+			return instance.BasicImageId, nil
+		}
+		return "", err
+	}
+
+	if image.ImageType == "Custom" {
+		return imageId, nil
+	}
+
+	return instance.BasicImageId, nil
+}
+
+// flattenInstanceDataDisks will match the data disks in state to the disk set of instance by disk id,
+// the data disks which have no disk id yet are just created, they are matched by the order of creation,
+// and the other data disks created with instance are appended, such as the disks of imported instance.
+func flattenInstanceDataDisks(dataDisks []interface{}, diskSet []uhost.UHostDiskSet) []map[string]interface{} {
+	var candidates []uhost.UHostDiskSet
+	for _, item := range diskSet {
+		// the udisk attached after instance created is not a data disk of instance
+		if item.IsBoot == "False" && item.Type != "Udisk" {
+			candidates = append(candidates, item)
+		}
+	}
+
+	matched := map[string]bool{}
+	result := []map[string]interface{}{}
+	for _, item := range dataDisks {
+		dataDisk := item.(map[string]interface{})
+		diskId := dataDisk["disk_id"].(string)
+
+		for _, disk := range candidates {
+			if matched[disk.DiskId] {
+				continue
+			}
+
+			if disk.DiskId == diskId || (diskId == "" && disk.DiskType == dataDisk["type"].(string)) {
+				matched[disk.DiskId] = true
+				result = append(result, flattenInstanceDataDisk(disk))
+				break
+			}
+		}
+	}
+
+	for _, disk := range candidates {
+		if !matched[disk.DiskId] {
+			result = append(result, flattenInstanceDataDisk(disk))
+		}
+	}
+
+	return result
+}
+
+func isLocalDiskType(diskType string) bool {
+	return diskType == "LOCAL_NORMAL" || diskType == "LOCAL_SSD"
+}
+
+func flattenInstanceDataDisk(disk uhost.UHostDiskSet) map[string]interface{} {
+	backupType := disk.BackupType
+	if backupType == "" {
+		backupType = "NONE"
+	}
+
+	return map[string]interface{}{
+		"type":        disk.DiskType,
+		"size":        disk.Size,
+		"backup_type": backupType,
+		"disk_id":     disk.DiskId,
+	}
+}
+
+// suppressUserDataDiff will ignore the change of user data after the instance is created if user_data_replace_on_change is false,
+// because the user data is only run at the first time the instance is started.
+func suppressUserDataDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && !d.Get("user_data_replace_on_change").(bool)
+}
+
 func instanceStateRefreshFunc(client *UCloudClient, instanceId, target string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		instance, err := client.describeInstanceById(instanceId)
@@ -663,3 +1278,19 @@ func instanceStateRefreshFunc(client *UCloudClient, instanceId, target string) r
 		return instance, state, nil
 	}
 }
+
+// instanceDataProtectionRefreshFunc will refresh the data ark feature of instance, it is "yes" if the data ark is enabled
+func instanceDataProtectionRefreshFunc(client *UCloudClient, instanceId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		instance, err := client.describeInstanceById(instanceId)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if strings.ToLower(instance.TimemachineFeature) != "yes" {
+			return instance, "pending", nil
+		}
+
+		return instance, "yes", nil
+	}
+}
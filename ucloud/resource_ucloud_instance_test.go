@@ -3,11 +3,15 @@ package ucloud
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/ucloud/ucloud-sdk-go/services/uhost"
+	"github.com/ucloud/ucloud-sdk-go/services/unet"
+	"github.com/ucloud/ucloud-sdk-go/ucloud"
 )
 
 func TestAccUCloudInstance_basic(t *testing.T) {
@@ -32,6 +36,7 @@ func TestAccUCloudInstance_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("ucloud_instance.foo", "instance_type", "n-highcpu-1"),
 					resource.TestCheckResourceAttr("ucloud_instance.foo", "cpu", "1"),
 					resource.TestCheckResourceAttr("ucloud_instance.foo", "memory", "1024"),
+					resource.TestMatchResourceAttr("ucloud_instance.foo", "private_mac", regexp.MustCompile("^([0-9a-f]{2}:){5}[0-9a-f]{2}$")),
 				),
 			},
 			resource.TestStep{
@@ -49,6 +54,76 @@ func TestAccUCloudInstance_basic(t *testing.T) {
 	})
 }
 
+func TestAccUCloudInstance_import(t *testing.T) {
+	var instance uhost.UHostInstanceSet
+	var sg unet.FirewallDataSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigImport,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &instance),
+					testAccCheckSecurityGroupExists("ucloud_security_group.bar", &sg),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "instance_type", "n-customized-1-2"),
+					resource.TestCheckResourceAttrPair("ucloud_instance.foo", "security_group", "ucloud_security_group.foo", "id"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "ucloud_instance.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"root_password", "instance_type", "instance_duration",
+					"running", "keep_data_disks_on_reinstall", "user_data_replace_on_change",
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 instance state, got %d", len(states))
+					}
+
+					if got := states[0].Attributes["instance_type"]; got != "n-basic-1" {
+						return fmt.Errorf("instance_type should be %q after import, got %q", "n-basic-1", got)
+					}
+					return nil
+				},
+			},
+			resource.TestStep{
+				// the security group is changed out of band, it should be detected as drift
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*UCloudClient).unetconn
+					req := conn.NewGrantFirewallRequest()
+					req.FWId = ucloud.String(sg.FWId)
+					req.ResourceType = ucloud.String("UHost")
+					req.ResourceId = ucloud.String(instance.UHostId)
+					if _, err := conn.GrantFirewall(req); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccInstanceConfigImport,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			resource.TestStep{
+				Config: testAccInstanceConfigImport,
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("ucloud_instance.foo", "security_group", "ucloud_security_group.foo", "id"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccUCloudInstance_vpc(t *testing.T) {
 	var instance uhost.UHostInstanceSet
 
@@ -110,6 +185,438 @@ func TestAccUCloudInstance_size(t *testing.T) {
 	})
 }
 
+func TestAccUCloudInstance_keyPair(t *testing.T) {
+	var instance uhost.UHostInstanceSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigKeyPair,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "name", "tf-testAccInstanceConfigKeyPair"),
+					resource.TestCheckResourceAttrPair("ucloud_instance.foo", "key_pair_id", "ucloud_key_pair.foo", "id"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "root_password", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudInstance_userData(t *testing.T) {
+	var instance uhost.UHostInstanceSet
+	var updated uhost.UHostInstanceSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigUserData, "echo hello", "true"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "user_data", userDataHashSum("#!/bin/sh\necho hello\n")),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigUserData, "echo ignored", "false"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &updated),
+					testAccCheckInstanceNotRecreated(&instance, &updated),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "user_data", userDataHashSum("#!/bin/sh\necho hello\n")),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigUserData, "echo replaced", "true"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &updated),
+					testAccCheckInstanceRecreated(&instance, &updated),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "user_data", userDataHashSum("#!/bin/sh\necho replaced\n")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudInstance_dataDisks(t *testing.T) {
+	var instance uhost.UHostInstanceSet
+	var updated uhost.UHostInstanceSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigDataDisks, 20, 30, ""),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.#", "3"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.0.type", "LOCAL_NORMAL"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.0.size", "20"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.1.type", "CLOUD_SSD"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.1.size", "30"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.2.type", "CLOUD_NORMAL"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.2.size", "40"),
+					resource.TestCheckResourceAttrSet("ucloud_instance.foo", "data_disks.2.disk_id"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigDataDisks, 30, 50, ""),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &updated),
+					testAccCheckInstanceNotRecreated(&instance, &updated),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.#", "3"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.0.size", "30"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.1.size", "50"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.2.size", "40"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigDataDisks, 30, 50, testAccInstanceConfigDataDisksExtra),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &updated),
+					testAccCheckInstanceRecreated(&instance, &updated),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.#", "4"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_disks.3.size", "60"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudInstance_privateIP(t *testing.T) {
+	var instance uhost.UHostInstanceSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigPrivateIP,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "private_ip", "192.168.1.100"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "private_mac", "52:54:00:12:34:56"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "public_ip", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudInstance_reinstall(t *testing.T) {
+	var instance uhost.UHostInstanceSet
+	var updated uhost.UHostInstanceSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigReinstall, "^CentOS 7.[1-2] 64"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &instance),
+					resource.TestCheckResourceAttrPair("ucloud_instance.foo", "image_id", "data.ucloud_images.default", "images.0.id"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigReinstall, "^Ubuntu 16.04 64"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &updated),
+					testAccCheckInstanceNotRecreated(&instance, &updated),
+					resource.TestCheckResourceAttrPair("ucloud_instance.foo", "image_id", "data.ucloud_images.default", "images.0.id"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "status", "Running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudInstance_gpu(t *testing.T) {
+	var instance uhost.UHostInstanceSet
+	var updated uhost.UHostInstanceSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigGPU, "g2-standard-8-2"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "cpu", "8"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "memory", "32768"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "gpu", "2"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "net_capability", "Super"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigGPU, "g2-standard-8-1"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &updated),
+					testAccCheckInstanceRecreated(&instance, &updated),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "gpu", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudInstance_power(t *testing.T) {
+	var instance uhost.UHostInstanceSet
+	var updated uhost.UHostInstanceSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigPower, "false", "1"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "running", "false"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "status", "Stopped"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigPower, "true", "1"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &updated),
+					testAccCheckInstanceNotRecreated(&instance, &updated),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "running", "true"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "status", "Running"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigPower, "true", "2"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &updated),
+					testAccCheckInstanceNotRecreated(&instance, &updated),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "reboot_trigger", "2"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "status", "Running"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigPower, "false", "2"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &updated),
+					testAccCheckInstanceNotRecreated(&instance, &updated),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "running", "false"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "status", "Stopped"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUCloudInstance_dataProtection(t *testing.T) {
+	var instance uhost.UHostInstanceSet
+	var updated uhost.UHostInstanceSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigDataProtection, "false"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_protection", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccInstanceConfigDataProtection, "true"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("ucloud_instance.foo", &updated),
+					testAccCheckInstanceNotRecreated(&instance, &updated),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "data_protection", "true"),
+					resource.TestCheckResourceAttr("ucloud_instance.foo", "status", "Running"),
+				),
+			},
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccInstanceConfigDataProtection, "false"),
+				ExpectError: regexp.MustCompile("data_protection cannot be disabled"),
+			},
+		},
+	})
+}
+
+// Test_resourceUCloudInstance_loginDiff checks the login of imported instance is adopted on the first apply,
+// and the later changes of root_password and key_pair_id still take effect.
+func Test_resourceUCloudInstance_loginDiff(t *testing.T) {
+	tests := []struct {
+		name            string
+		state           map[string]string
+		config          map[string]interface{}
+		key             string
+		wantNew         string
+		wantRequiresNew bool
+	}{
+		{
+			"adopt root_password after import",
+			map[string]string{},
+			map[string]interface{}{"root_password": "wA1234567"},
+			"root_password",
+			"wA1234567",
+			false,
+		},
+		{
+			"adopt key_pair_id after import",
+			map[string]string{},
+			map[string]interface{}{"key_pair_id": "uhostkp-foo"},
+			"key_pair_id",
+			"uhostkp-foo",
+			false,
+		},
+		{
+			"change root_password after import",
+			map[string]string{"root_password": "wA1234567"},
+			map[string]interface{}{"root_password": "wB1234567"},
+			"root_password",
+			"wB1234567",
+			false,
+		},
+		{
+			"change key_pair_id after import",
+			map[string]string{"key_pair_id": "uhostkp-foo"},
+			map[string]interface{}{"key_pair_id": "uhostkp-bar"},
+			"key_pair_id",
+			"uhostkp-bar",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := map[string]string{
+				"availability_zone": "cn-sh2-02",
+				"image_id":          "uimage-foo",
+				"instance_type":     "n-basic-1",
+			}
+			raw := map[string]interface{}{
+				"availability_zone": "cn-sh2-02",
+				"image_id":          "uimage-foo",
+				"instance_type":     "n-basic-1",
+			}
+			for k, v := range tt.state {
+				attributes[k] = v
+			}
+			for k, v := range tt.config {
+				raw[k] = v
+			}
+
+			c, err := config.NewRawConfig(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			state := &terraform.InstanceState{ID: "uhost-foo", Attributes: attributes}
+			diff, err := resourceUCloudInstance().Diff(state, terraform.NewResourceConfig(c), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff == nil || diff.Attributes[tt.key] == nil {
+				t.Fatalf("expected a diff of %s, got none", tt.key)
+			}
+
+			attr := diff.Attributes[tt.key]
+			if attr.New != tt.wantNew {
+				t.Errorf("expected new %s %q, got %q", tt.key, tt.wantNew, attr.New)
+			}
+			if attr.RequiresNew != tt.wantRequiresNew {
+				t.Errorf("expected %s requires new %v, got %v", tt.key, tt.wantRequiresNew, attr.RequiresNew)
+			}
+		})
+	}
+}
+
+func testAccCheckInstanceNotRecreated(before, after *uhost.UHostInstanceSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.UHostId != after.UHostId {
+			return fmt.Errorf("instance %s is recreated as %s", before.UHostId, after.UHostId)
+		}
+		return nil
+	}
+}
+
+func testAccCheckInstanceRecreated(before, after *uhost.UHostInstanceSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.UHostId == after.UHostId {
+			return fmt.Errorf("instance %s should be recreated", before.UHostId)
+		}
+		return nil
+	}
+}
+
 func testAccCheckInstanceExists(n string, instance *uhost.UHostInstanceSet) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -368,3 +875,244 @@ resource "ucloud_instance" "foo" {
 	data_disk_size = 100
 }
 `
+
+const testAccInstanceConfigKeyPair = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_key_pair" "foo" {
+	key_name   = "tf-testAccInstanceConfigKeyPair"
+	public_key = "` + testAccKeyPairPublicKey + `"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	key_pair_id = "${ucloud_key_pair.foo.id}"
+	name = "tf-testAccInstanceConfigKeyPair"
+	instance_type = "n-highcpu-1"
+}
+`
+
+const testAccInstanceConfigUserData = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccInstanceConfigUserData"
+	instance_type = "n-highcpu-1"
+	user_data = "#!/bin/sh\n%s\n"
+	user_data_replace_on_change = %s
+}
+`
+
+const testAccInstanceConfigDataDisks = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccInstanceConfigDataDisks"
+	instance_type = "n-highcpu-1"
+
+	data_disks {
+		type = "LOCAL_NORMAL"
+		size = %d
+	}
+
+	data_disks {
+		type = "CLOUD_SSD"
+		size = %d
+	}
+
+	data_disks {
+		type = "CLOUD_NORMAL"
+		size = 40
+	}
+	%s
+}
+`
+
+const testAccInstanceConfigDataDisksExtra = `
+	data_disks {
+		type = "CLOUD_SSD"
+		size = 60
+	}
+`
+
+const testAccInstanceConfigPrivateIP = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_vpc" "default" {
+	name = "tf-testAccInstanceConfigPrivateIP"
+	cidr_blocks = ["192.168.0.0/16"]
+}
+
+resource "ucloud_subnet" "default" {
+	name = "tf-testAccInstanceConfigPrivateIP"
+	cidr_block = "192.168.1.0/24"
+	vpc_id = "${ucloud_vpc.default.id}"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccInstanceConfigPrivateIP"
+	instance_type = "n-highcpu-1"
+	vpc_id = "${ucloud_vpc.default.id}"
+	subnet_id = "${ucloud_subnet.default.id}"
+	private_ip = "192.168.1.100"
+	private_mac = "52:54:00:12:34:56"
+}
+`
+
+const testAccInstanceConfigReinstall = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "%s"
+	image_type =  "Base"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccInstanceConfigReinstall"
+	instance_type = "n-highcpu-1"
+	data_disk_size = 50
+}
+`
+
+const testAccInstanceConfigPower = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccInstanceConfigPower"
+	instance_type = "n-highcpu-1"
+	running = %s
+	reboot_trigger = "%s"
+}
+`
+
+const testAccInstanceConfigGPU = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccInstanceConfigGPU"
+	instance_type = "%s"
+	net_capability = "Super"
+	instance_charge_type = "Dynamic"
+}
+`
+
+const testAccInstanceConfigImport = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_security_group" "foo" {
+	name = "tf-testAccInstanceConfigImport"
+	rules {
+		port_range = "80"
+		protocol   = "TCP"
+		cidr_block = "192.168.0.0/16"
+	}
+}
+
+resource "ucloud_security_group" "bar" {
+	name = "tf-testAccInstanceConfigImportDrift"
+	rules {
+		port_range = "22"
+		protocol   = "TCP"
+		cidr_block = "192.168.0.0/16"
+	}
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccInstanceConfigImport"
+	instance_type = "n-customized-1-2"
+	security_group = "${ucloud_security_group.foo.id}"
+}
+`
+
+const testAccInstanceConfigDataProtection = `
+data "ucloud_zones" "default" {
+}
+
+data "ucloud_images" "default" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	name_regex = "^CentOS 7.[1-2] 64"
+	image_type =  "Base"
+}
+
+resource "ucloud_instance" "foo" {
+	availability_zone = "${data.ucloud_zones.default.zones.0.id}"
+	image_id = "${data.ucloud_images.default.images.0.id}"
+	root_password = "wA1234567"
+	name = "tf-testAccInstanceConfigDataProtection"
+	instance_type = "n-highcpu-1"
+	data_protection = %s
+}
+`
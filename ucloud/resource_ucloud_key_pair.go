@@ -0,0 +1,116 @@
+package ucloud
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceUCloudKeyPair() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUCloudKeyPairCreate,
+		Read:   resourceUCloudKeyPairRead,
+		Delete: resourceUCloudKeyPairDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"key_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKeyPairName,
+			},
+
+			"public_key": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKeyPairPublicKey,
+				StateFunc: func(v interface{}) string {
+					return strings.TrimSpace(v.(string))
+				},
+				// the public key is empty after import, it should not force a new key pair
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != "" && old == ""
+				},
+			},
+
+			"fingerprint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceUCloudKeyPairCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*UCloudClient)
+
+	keyPair, err := client.importKeyPair(d.Get("key_name").(string), strings.TrimSpace(d.Get("public_key").(string)))
+	if err != nil {
+		return fmt.Errorf("error in create key pair, %s", err)
+	}
+
+	d.SetId(keyPair.KeyPairId)
+
+	return resourceUCloudKeyPairRead(d, meta)
+}
+
+func resourceUCloudKeyPairRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*UCloudClient)
+
+	keyPair, err := client.describeKeyPairById(d.Id())
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return newActionError("DescribeUHostKeyPairs", "key pair", d.Id(), err)
+	}
+
+	// the public key is not returned by api, it is kept in state
+	d.Set("key_name", keyPair.KeyPairName)
+	d.Set("fingerprint", keyPair.KeyPairFingerPrint)
+	d.Set("create_time", timestampToString(keyPair.CreateTime))
+
+	return nil
+}
+
+func resourceUCloudKeyPairDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*UCloudClient)
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		if err := client.deleteKeyPair(d.Id()); err != nil {
+			if isNotFoundError(err) {
+				return nil
+			}
+			if isResourceInUseError(err) {
+				return resource.RetryableError(newActionError("DeleteUHostKeyPairs", "key pair", d.Id(), err))
+			}
+			return resource.NonRetryableError(newActionError("DeleteUHostKeyPairs", "key pair", d.Id(), err))
+		}
+
+		if _, err := client.describeKeyPairById(d.Id()); err != nil {
+			if isNotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(newActionError("DescribeUHostKeyPairs", "key pair", d.Id(), err))
+		}
+
+		return resource.RetryableError(fmt.Errorf("delete key pair but it still exists"))
+	})
+}
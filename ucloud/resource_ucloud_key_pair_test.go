@@ -0,0 +1,102 @@
+package ucloud
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccUCloudKeyPair_basic(t *testing.T) {
+	var keyPair uhostKeyPair
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ucloud_key_pair.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKeyPairDestroy,
+
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccKeyPairConfig,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeyPairExists("ucloud_key_pair.foo", &keyPair),
+					resource.TestCheckResourceAttr("ucloud_key_pair.foo", "key_name", "tf-testAccKeyPairConfig"),
+					resource.TestCheckResourceAttrSet("ucloud_key_pair.foo", "fingerprint"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "ucloud_key_pair.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"public_key"},
+			},
+		},
+	})
+}
+
+func testAccCheckKeyPairExists(n string, keyPair *uhostKeyPair) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("key pair id is empty")
+		}
+
+		client := testAccProvider.Meta().(*UCloudClient)
+		ptr, err := client.describeKeyPairById(rs.Primary.ID)
+
+		log.Printf("[INFO] key pair id %#v", rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		*keyPair = *ptr
+		return nil
+	}
+}
+
+func testAccCheckKeyPairDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ucloud_key_pair" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*UCloudClient)
+		keyPair, err := client.describeKeyPairById(rs.Primary.ID)
+
+		// Verify the error is what we want
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+
+		if keyPair.KeyPairId != "" {
+			return fmt.Errorf("key pair still exist")
+		}
+	}
+
+	return nil
+}
+
+const testAccKeyPairPublicKey = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCwK2v82Qpo6lMDeMKyZl1hPkzCkd094y27dtaxJIDDfinzecabg4VfKp+OMVzlTPq6DQJNaZVQgYRk2lKsee392FxKWGJVc0xFuc4406bQlWYf4g61/+LUd+GfGJZOFjPyckud4B6lyzLmPZ93goR8wBj7NhvKsT8Ro9BC9wAq3w== terraform-acc"
+
+const testAccKeyPairConfig = `
+resource "ucloud_key_pair" "foo" {
+	key_name   = "tf-testAccKeyPairConfig"
+	public_key = "` + testAccKeyPairPublicKey + `"
+}
+`
@@ -19,6 +19,11 @@ func resourceUCloudLB() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"internal": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -147,7 +152,7 @@ func resourceUCloudLBCreate(d *schema.ResourceData, meta interface{}) error {
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"initialized"},
-		Timeout:    5 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutCreate),
 		Delay:      2 * time.Second,
 		MinTimeout: 1 * time.Second,
 		Refresh: func() (interface{}, string, error) {
@@ -220,7 +225,7 @@ func resourceUCloudLBRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read lb %s, %s", "DescribeULB", d.Id(), err)
+		return newActionError("DescribeULB", "lb", d.Id(), err)
 	}
 
 	d.Set("name", lbSet.Name)
@@ -255,9 +260,9 @@ func resourceUCloudLBDelete(d *schema.ResourceData, meta interface{}) error {
 	req := conn.NewDeleteULBRequest()
 	req.ULBId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if _, err := conn.DeleteULB(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete lb %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeleteULB", "lb", d.Id(), err))
 		}
 
 		_, err := client.describeLBById(d.Id())
@@ -266,7 +271,7 @@ func resourceUCloudLBDelete(d *schema.ResourceData, meta interface{}) error {
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete lb %s, %s", "DescribeULB", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeULB", "lb", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete lb but it still exists"))
@@ -16,6 +16,11 @@ func resourceUCloudLBAttachment() *schema.Resource {
 		Update: resourceUCloudLBAttachmentUpdate,
 		Delete: resourceUCloudLBAttachmentDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"load_balancer_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -86,7 +91,7 @@ func resourceUCloudLBAttachmentCreate(d *schema.ResourceData, meta interface{})
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"initialized"},
-		Timeout:    10 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutCreate),
 		Delay:      5 * time.Second,
 		MinTimeout: 3 * time.Second,
 		Refresh: func() (interface{}, string, error) {
@@ -158,7 +163,7 @@ func resourceUCloudLBAttachmentRead(d *schema.ResourceData, meta interface{}) er
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read lb attachment %s, %s", "DescribeVServer", d.Id(), err)
+		return newActionError("DescribeVServer", "lb attachment", d.Id(), err)
 	}
 
 	d.Set("resource_id", backendSet.ResourceId)
@@ -181,10 +186,10 @@ func resourceUCloudLBAttachmentDelete(d *schema.ResourceData, meta interface{})
 	req.ULBId = ucloud.String(lbId)
 	req.BackendId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 
 		if _, err := conn.ReleaseBackend(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete lb attachment %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("ReleaseBackend", "lb attachment", d.Id(), err))
 		}
 
 		_, err := client.describeBackendById(lbId, listenerId, d.Id())
@@ -193,7 +198,7 @@ func resourceUCloudLBAttachmentDelete(d *schema.ResourceData, meta interface{})
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete lb attachment %s, %s", "DescribeVServer", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeVServer", "lb attachment", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete lb attachment but it still exists"))
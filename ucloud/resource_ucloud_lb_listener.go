@@ -16,6 +16,11 @@ func resourceUCloudLBListener() *schema.Resource {
 		Read:   resourceUCloudLBListenerRead,
 		Delete: resourceUCloudLBListenerDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"load_balancer_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -151,7 +156,7 @@ func resourceUCloudLBListenerCreate(d *schema.ResourceData, meta interface{}) er
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"initialized"},
-		Timeout:    10 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutCreate),
 		Delay:      5 * time.Second,
 		MinTimeout: 3 * time.Second,
 		Refresh: func() (interface{}, string, error) {
@@ -270,7 +275,7 @@ func resourceUCloudLBListenerRead(d *schema.ResourceData, meta interface{}) erro
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read lb listener %s, %s", "DescribeVServer", d.Id(), err)
+		return newActionError("DescribeVServer", "lb listener", d.Id(), err)
 	}
 
 	d.Set("name", vserverSet.VServerName)
@@ -298,9 +303,9 @@ func resourceUCloudLBListenerDelete(d *schema.ResourceData, meta interface{}) er
 	req.ULBId = ucloud.String(lbId)
 	req.VServerId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if _, err := conn.DeleteVServer(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete lb listener %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeleteVServer", "lb listener", d.Id(), err))
 		}
 
 		_, err := client.describeVServerById(lbId, d.Id())
@@ -309,7 +314,7 @@ func resourceUCloudLBListenerDelete(d *schema.ResourceData, meta interface{}) er
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete lb listener %s, %s", "DescribeVServer", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeVServer", "lb listener", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete lb listener but it still exists"))
@@ -16,6 +16,12 @@ func resourceUCloudLBRule() *schema.Resource {
 		Read:   resourceUCloudLBRuleRead,
 		Delete: resourceUCloudLBRuleDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"load_balancer_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -64,7 +70,7 @@ func resourceUCloudLBRuleCreate(d *schema.ResourceData, meta interface{}) error
 	req := conn.NewCreatePolicyRequest()
 	req.ULBId = ucloud.String(lbId)
 	req.VServerId = ucloud.String(listenerId)
-	req.BackendId = ifaceToStringSlice(d.Get("backend_ids"))
+	req.BackendId = ifaceToStringSlice(d.Get("backend_ids").(*schema.Set).List())
 
 	if val, ok := d.GetOk("domain"); ok {
 		req.Type = ucloud.String("Domain")
@@ -85,7 +91,7 @@ func resourceUCloudLBRuleCreate(d *schema.ResourceData, meta interface{}) error
 	d.SetId(resp.PolicyId)
 
 	// after create lb rule, we need to wait it initialized
-	stateConf := lbRuleWaitForState(client, lbId, listenerId, d.Id())
+	stateConf := lbRuleWaitForState(client, lbId, listenerId, d.Id(), d.Timeout(schema.TimeoutCreate))
 
 	_, err = stateConf.WaitForState()
 
@@ -109,7 +115,7 @@ func resourceUCloudLBRuleUpdate(d *schema.ResourceData, meta interface{}) error
 	req := conn.NewUpdatePolicyRequest()
 	req.ULBId = ucloud.String(lbId)
 	req.VServerId = ucloud.String(listenerId)
-	req.BackendId = ifaceToStringSlice(d.Get("backend_ids"))
+	req.BackendId = ifaceToStringSlice(d.Get("backend_ids").(*schema.Set).List())
 	req.PolicyId = ucloud.String(d.Id())
 
 	if d.HasChange("domain") && !d.IsNewResource() {
@@ -134,7 +140,7 @@ func resourceUCloudLBRuleUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 
 		// after update lb rule, we need to wait it completed
-		stateConf := lbRuleWaitForState(client, lbId, listenerId, d.Id())
+		stateConf := lbRuleWaitForState(client, lbId, listenerId, d.Id(), d.Timeout(schema.TimeoutUpdate))
 
 		_, err = stateConf.WaitForState()
 
@@ -161,7 +167,7 @@ func resourceUCloudLBRuleRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read lb rule %s, %s", "DescribeVServer", d.Id(), err)
+		return newActionError("DescribeVServer", "lb rule", d.Id(), err)
 	}
 
 	if policySet.Type == "Path" {
@@ -186,9 +192,9 @@ func resourceUCloudLBRuleDelete(d *schema.ResourceData, meta interface{}) error
 	req.VServerId = ucloud.String(listenerId)
 	req.PolicyId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if _, err := conn.DeletePolicy(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete lb rule %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeletePolicy", "lb rule", d.Id(), err))
 		}
 
 		_, err := client.describePolicyById(lbId, listenerId, d.Id())
@@ -197,18 +203,18 @@ func resourceUCloudLBRuleDelete(d *schema.ResourceData, meta interface{}) error
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete lb rule %s, %s", "DescribeVServer", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeVServer", "lb rule", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete lb rule but still exists"))
 	})
 }
 
-func lbRuleWaitForState(client *UCloudClient, lbId, listenerId, policyId string) *resource.StateChangeConf {
+func lbRuleWaitForState(client *UCloudClient, lbId, listenerId, policyId string, timeout time.Duration) *resource.StateChangeConf {
 	return &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"initialized"},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      2 * time.Second,
 		MinTimeout: 1 * time.Second,
 		Refresh: func() (interface{}, string, error) {
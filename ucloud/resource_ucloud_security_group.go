@@ -21,6 +21,12 @@ func resourceUCloudSecurityGroup() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:         schema.TypeString,
@@ -117,7 +123,7 @@ func resourceUCloudSecurityGroupCreate(d *schema.ResourceData, meta interface{})
 	d.SetId(resp.FWId)
 
 	// after create security group, we need to wait it initialized
-	stateConf := securityWaitForState(client, d.Id())
+	stateConf := securityWaitForState(client, d.Id(), d.Timeout(schema.TimeoutCreate))
 
 	_, err = stateConf.WaitForState()
 	if err != nil {
@@ -145,7 +151,7 @@ func resourceUCloudSecurityGroupUpdate(d *schema.ResourceData, meta interface{})
 		}
 
 		// after update security group rule, we need to wait it completed
-		stateConf := securityWaitForState(client, d.Id())
+		stateConf := securityWaitForState(client, d.Id(), d.Timeout(schema.TimeoutUpdate))
 
 		_, err = stateConf.WaitForState()
 		if err != nil {
@@ -183,7 +189,7 @@ func resourceUCloudSecurityGroupUpdate(d *schema.ResourceData, meta interface{})
 		}
 
 		// after update security group attribute, we need to wait it completed
-		stateConf := securityWaitForState(client, d.Id())
+		stateConf := securityWaitForState(client, d.Id(), d.Timeout(schema.TimeoutUpdate))
 
 		_, err = stateConf.WaitForState()
 		if err != nil {
@@ -205,7 +211,7 @@ func resourceUCloudSecurityGroupRead(d *schema.ResourceData, meta interface{}) e
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read security group %s, %s", "DescribeFirewall", d.Id(), err)
+		return newActionError("DescribeFirewall", "security group", d.Id(), err)
 	}
 
 	d.Set("name", sgSet.Name)
@@ -235,9 +241,9 @@ func resourceUCloudSecurityGroupDelete(d *schema.ResourceData, meta interface{})
 	req := conn.NewDeleteFirewallRequest()
 	req.FWId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if _, err := conn.DeleteFirewall(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete security group %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeleteFirewall", "security group", d.Id(), err))
 		}
 
 		_, err := client.describeFirewallById(d.Id())
@@ -246,7 +252,7 @@ func resourceUCloudSecurityGroupDelete(d *schema.ResourceData, meta interface{})
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete security group %s, %s", "DescribeFirewall", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeFirewall", "security group", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete security group but it still exists"))
@@ -284,11 +290,11 @@ func buildRuleParameter(iface interface{}) []string {
 	return rules
 }
 
-func securityWaitForState(client *UCloudClient, sgId string) *resource.StateChangeConf {
+func securityWaitForState(client *UCloudClient, sgId string, timeout time.Duration) *resource.StateChangeConf {
 	return &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"initialized"},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      2 * time.Second,
 		MinTimeout: 1 * time.Second,
 		Refresh: func() (interface{}, string, error) {
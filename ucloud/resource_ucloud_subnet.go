@@ -19,6 +19,12 @@ func resourceUCloudSubnet() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"cidr_block": &schema.Schema{
 				Type:         schema.TypeString,
@@ -90,7 +96,7 @@ func resourceUCloudSubnetCreate(d *schema.ResourceData, meta interface{}) error
 	d.SetId(resp.SubnetId)
 
 	// after create subnet, we need to wait it initialized
-	stateConf := subnetWaitForState(client, d.Id())
+	stateConf := subnetWaitForState(client, d.Id(), d.Timeout(schema.TimeoutCreate))
 
 	_, err = stateConf.WaitForState()
 	if err != nil {
@@ -130,7 +136,7 @@ func resourceUCloudSubnetUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 
 		// after update subnet attribute, we need to wait it completed
-		stateConf := subnetWaitForState(client, d.Id())
+		stateConf := subnetWaitForState(client, d.Id(), d.Timeout(schema.TimeoutUpdate))
 
 		_, err = stateConf.WaitForState()
 		if err != nil {
@@ -152,7 +158,7 @@ func resourceUCloudSubnetRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read subnet %s, %s", "DescribeSubnet", d.Id(), err)
+		return newActionError("DescribeSubnet", "subnet", d.Id(), err)
 	}
 
 	d.Set("name", subnetSet.SubnetName)
@@ -172,9 +178,9 @@ func resourceUCloudSubnetDelete(d *schema.ResourceData, meta interface{}) error
 	req := conn.NewDeleteSubnetRequest()
 	req.SubnetId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if _, err := conn.DeleteSubnet(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete subnet %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeleteSubnet", "subnet", d.Id(), err))
 		}
 
 		_, err := client.describeSubnetById(d.Id())
@@ -183,18 +189,18 @@ func resourceUCloudSubnetDelete(d *schema.ResourceData, meta interface{}) error
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete subnet %s, %s", "DescribeSubnet", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeSubnet", "subnet", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete subnet but it still exists"))
 	})
 }
 
-func subnetWaitForState(client *UCloudClient, subnetId string) *resource.StateChangeConf {
+func subnetWaitForState(client *UCloudClient, subnetId string, timeout time.Duration) *resource.StateChangeConf {
 	return &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"initialized"},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      2 * time.Second,
 		MinTimeout: 1 * time.Second,
 		Refresh: func() (interface{}, string, error) {
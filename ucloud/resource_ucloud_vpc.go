@@ -19,6 +19,11 @@ func resourceUCloudVPC() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -101,7 +106,7 @@ func resourceUCloudVPCCreate(d *schema.ResourceData, meta interface{}) error {
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"initialized"},
-		Timeout:    5 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutCreate),
 		Delay:      2 * time.Second,
 		MinTimeout: 1 * time.Second,
 		Refresh: func() (interface{}, string, error) {
@@ -138,7 +143,7 @@ func resourceUCloudVPCRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read vpc %s, %s", "DescribeVPC", d.Id(), err)
+		return newActionError("DescribeVPC", "vpc", d.Id(), err)
 	}
 
 	d.Set("name", vpcSet.Name)
@@ -169,9 +174,9 @@ func resourceUCloudVPCDelete(d *schema.ResourceData, meta interface{}) error {
 	req := conn.NewDeleteVPCRequest()
 	req.VPCId = ucloud.String(d.Id())
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if _, err := conn.DeleteVPC(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete vpc %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeleteVPC", "vpc", d.Id(), err))
 		}
 
 		_, err := client.describeVPCById(d.Id())
@@ -180,7 +185,7 @@ func resourceUCloudVPCDelete(d *schema.ResourceData, meta interface{}) error {
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete vpc %s, %s", "DescribeVPC", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeVPC", "vpc", d.Id(), err))
 		}
 
 		return resource.RetryableError(fmt.Errorf("delete vpc but it still exists"))
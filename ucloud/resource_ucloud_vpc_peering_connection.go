@@ -16,6 +16,11 @@ func resourceUCloudVPCPeeringConnection() *schema.Resource {
 		Read:   resourceUCloudVPCPeeringConnectionRead,
 		Delete: resourceUCloudVPCPeeringConnectionDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vpc_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -73,7 +78,7 @@ func resourceUCloudVPCPeeringConnectionCreate(d *schema.ResourceData, meta inter
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"initialized"},
-		Timeout:    5 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutCreate),
 		Delay:      2 * time.Second,
 		MinTimeout: 1 * time.Second,
 		Refresh: func() (interface{}, string, error) {
@@ -116,7 +121,7 @@ func resourceUCloudVPCPeeringConnectionRead(d *schema.ResourceData, meta interfa
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("do %s failed in read vpc peering connection %s, %s", "DescribeVPCIntercom", d.Id(), err)
+		return newActionError("DescribeVPCIntercom", "vpc peering connection", d.Id(), err)
 	}
 
 	d.Set("vpc_id", d.Get("vpc_id").(string))
@@ -145,10 +150,10 @@ func resourceUCloudVPCPeeringConnectionDelete(d *schema.ResourceData, meta inter
 	req.DstRegion = ucloud.String(peerRegion)
 	req.DstProjectId = ucloud.String(peerProjectId)
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		// retry by sdk implementations
 		if _, err := conn.DeleteVPCIntercom(req); err != nil {
-			return resource.NonRetryableError(fmt.Errorf("error in delete vpc peering connection %s, %s", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DeleteVPCIntercom", "vpc peering connection", d.Id(), err))
 		}
 
 		_, err = client.describeVPCIntercomById(assoc.PrimaryId, assoc.ResourceId, peerRegion, peerProjectId)
@@ -157,7 +162,7 @@ func resourceUCloudVPCPeeringConnectionDelete(d *schema.ResourceData, meta inter
 			if isNotFoundError(err) {
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("do %s failed in delete vpc peering connection %s, %s", "DescribeVPCIntercom", d.Id(), err))
+			return resource.NonRetryableError(newActionError("DescribeVPCIntercom", "vpc peering connection", d.Id(), err))
 		}
 
 		// delete but it still exists
@@ -17,7 +17,8 @@ func (client *UCloudClient) describeDBInstanceById(dbInstanceId string) (*udb.UD
 
 	resp, err := client.udbconn.DescribeUDBInstance(req)
 	if err != nil {
-		if uErr, ok := err.(uerr.Error); ok && uErr.Code() == 230 {
+		// the db instance is not exist if the DBId is not available
+		if uErr, ok := err.(uerr.Error); ok && uErr.Code() == paramsNotAvailableRetCode {
 			return nil, newNotFoundError(getNotFoundMessage("db_instance", dbInstanceId))
 		}
 		return nil, err
@@ -41,7 +42,7 @@ func (client *UCloudClient) describeDBParameterGroupByIdAndZone(paramGroupId, zo
 
 	resp, err := client.udbconn.DescribeUDBParamGroup(req)
 	if err != nil {
-		if uErr, ok := err.(uerr.Error); ok && uErr.Code() == 7011 {
+		if isNotFoundError(err) {
 			return nil, newNotFoundError(getNotFoundMessage("db_param_group", paramGroupId))
 		}
 		return nil, err
@@ -75,11 +76,11 @@ func (client *UCloudClient) describeDBBackupByIdAndZone(backupId, zone string) (
 	return &resp.DataSet[0], nil
 }
 
-func (client *UCloudClient) dbWaitForState(dbId string, target []string) *resource.StateChangeConf {
+func (client *UCloudClient) dbWaitForState(dbId string, target []string, timeout time.Duration) *resource.StateChangeConf {
 	return &resource.StateChangeConf{
 		Pending:    []string{statusPending},
 		Target:     target,
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      5 * time.Second,
 		MinTimeout: 3 * time.Second,
 		Refresh: func() (interface{}, string, error) {
@@ -5,6 +5,53 @@ import (
 	"github.com/ucloud/ucloud-sdk-go/ucloud"
 )
 
+// cloneUDiskRequest is used to clone disk with tag,
+// the Tag is supported by the api but missing in the sdk request, so that it is added here until the sdk supports it.
+type cloneUDiskRequest struct {
+	udisk.CloneUDiskRequest
+
+	Tag *string
+}
+
+// cloneUDiskSnapshotRequest is used to clone disk from snapshot with tag, see cloneUDiskRequest.
+type cloneUDiskSnapshotRequest struct {
+	udisk.CloneUDiskSnapshotRequest
+
+	Tag *string
+}
+
+func (client *UCloudClient) cloneDisk(req *udisk.CloneUDiskRequest, tag string) (*udisk.CloneUDiskResponse, error) {
+	conn := client.udiskgenericconn
+
+	tagReq := &cloneUDiskRequest{CloneUDiskRequest: *req}
+	if tag != "" {
+		tagReq.Tag = ucloud.String(tag)
+	}
+
+	var resp udisk.CloneUDiskResponse
+	if err := conn.InvokeAction("CloneUDisk", tagReq, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (client *UCloudClient) cloneDiskSnapshot(req *udisk.CloneUDiskSnapshotRequest, tag string) (*udisk.CloneUDiskSnapshotResponse, error) {
+	conn := client.udiskgenericconn
+
+	tagReq := &cloneUDiskSnapshotRequest{CloneUDiskSnapshotRequest: *req}
+	if tag != "" {
+		tagReq.Tag = ucloud.String(tag)
+	}
+
+	var resp udisk.CloneUDiskSnapshotResponse
+	if err := conn.InvokeAction("CloneUDiskSnapshot", tagReq, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 func (client *UCloudClient) describeDiskById(diskId string) (*udisk.UDiskDataSet, error) {
 	req := client.udiskconn.NewDescribeUDiskRequest()
 	req.UDiskId = ucloud.String(diskId)
@@ -43,3 +90,19 @@ func (client *UCloudClient) describeDiskResource(diskId, resourceId string) (*ud
 
 	return nil, newNotFoundError(getNotFoundMessage("disk_attachment", diskId))
 }
+
+func (client *UCloudClient) describeDiskSnapshotById(snapshotId string) (*udisk.UDiskSnapshotSet, error) {
+	req := client.udiskconn.NewDescribeUDiskSnapshotRequest()
+	req.SnapshotId = ucloud.String(snapshotId)
+
+	resp, err := client.udiskconn.DescribeUDiskSnapshot(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.DataSet) < 1 {
+		return nil, newNotFoundError(getNotFoundMessage("disk_snapshot", snapshotId))
+	}
+
+	return &resp.DataSet[0], nil
+}
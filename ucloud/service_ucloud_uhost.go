@@ -3,6 +3,8 @@ package ucloud
 import (
 	"github.com/ucloud/ucloud-sdk-go/services/uhost"
 	"github.com/ucloud/ucloud-sdk-go/ucloud"
+	"github.com/ucloud/ucloud-sdk-go/ucloud/request"
+	"github.com/ucloud/ucloud-sdk-go/ucloud/response"
 )
 
 func (client *UCloudClient) describeInstanceById(instanceId string) (*uhost.UHostInstanceSet, error) {
@@ -29,8 +31,216 @@ func (client *UCloudClient) DescribeImageById(imageId string) (*uhost.UHostImage
 		return nil, err
 	}
 	if len(resp.ImageSet) < 1 {
-		return nil, newNotFoundError(getNotFoundMessage("instance", imageId))
+		return nil, newNotFoundError(getNotFoundMessage("image", imageId))
 	}
 
 	return &resp.ImageSet[0], nil
 }
+
+// updateImageAttributeRequest is used to update the name and description of custom image,
+// the action is not supported by the sdk yet.
+type updateImageAttributeRequest struct {
+	request.CommonBase
+
+	ImageId          *string
+	ImageName        *string
+	ImageDescription *string
+}
+
+type updateImageAttributeResponse struct {
+	response.CommonBase
+
+	ImageId string
+}
+
+func (client *UCloudClient) updateImageAttribute(imageId, name, description string) error {
+	conn := client.uhostgenericconn
+
+	req := &updateImageAttributeRequest{}
+	conn.SetupRequest(req)
+	req.ImageId = ucloud.String(imageId)
+	req.ImageName = ucloud.String(name)
+	req.ImageDescription = ucloud.String(description)
+
+	var resp updateImageAttributeResponse
+	return conn.InvokeAction("UpdateImageAttribute", req, &resp)
+}
+
+// uhostKeyPair is the key pair of uhost, the key pair actions are not supported by the sdk yet,
+// so that they are invoked by the generic connection of uhost.
+type uhostKeyPair struct {
+	ProjectId          string
+	KeyPairId          string
+	KeyPairName        string
+	KeyPairFingerPrint string
+	CreateTime         int
+}
+
+type importUHostKeyPairsRequest struct {
+	request.CommonBase
+
+	KeyPairName   *string
+	PublicKeyBody *string
+}
+
+type importUHostKeyPairsResponse struct {
+	response.CommonBase
+
+	KeyPair uhostKeyPair
+}
+
+type describeUHostKeyPairsRequest struct {
+	request.CommonBase
+
+	KeyPairName *string
+	Offset      *int
+	Limit       *int
+}
+
+type describeUHostKeyPairsResponse struct {
+	response.CommonBase
+
+	KeyPairs   []uhostKeyPair
+	TotalCount int
+}
+
+type deleteUHostKeyPairsRequest struct {
+	request.CommonBase
+
+	KeyPairIds []string
+}
+
+type deleteUHostKeyPairsResponse struct {
+	response.CommonBase
+}
+
+// uhostIPSet is the ip set of uhost with the mac address, because the Mac is not in the response of sdk.
+type uhostIPSet struct {
+	Type string
+	IP   string
+	Mac  string
+}
+
+type describeUHostIPSetRequest struct {
+	request.CommonBase
+
+	UHostIds []string
+}
+
+type describeUHostIPSetResponse struct {
+	response.CommonBase
+
+	UHostSet []struct {
+		UHostId string
+		IPSet   []uhostIPSet
+	}
+}
+
+// createUHostInstanceRequest is used to create instance with key pair.
+// The KeyPair of sdk request is marked as unsupported and means the public key body,
+// but the api logs in by the KeyPairId of the key pair imported by ImportUHostKeyPairs
+// when LoginMode is KeyPair, so that the KeyPairId is added here until the sdk supports it.
+type createUHostInstanceRequest struct {
+	uhost.CreateUHostInstanceRequest
+
+	KeyPairId *string
+}
+
+func (client *UCloudClient) importKeyPair(name, publicKey string) (*uhostKeyPair, error) {
+	conn := client.uhostgenericconn
+
+	req := &importUHostKeyPairsRequest{}
+	conn.SetupRequest(req)
+	req.KeyPairName = ucloud.String(name)
+	req.PublicKeyBody = ucloud.String(publicKey)
+
+	var resp importUHostKeyPairsResponse
+	if err := conn.InvokeAction("ImportUHostKeyPairs", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.KeyPair, nil
+}
+
+func (client *UCloudClient) describeKeyPairById(keyPairId string) (*uhostKeyPair, error) {
+	conn := client.uhostgenericconn
+
+	req := &describeUHostKeyPairsRequest{}
+	conn.SetupRequest(req)
+	req.Limit = ucloud.Int(100)
+
+	for offset := 0; ; offset += 100 {
+		req.Offset = ucloud.Int(offset)
+
+		var resp describeUHostKeyPairsResponse
+		if err := conn.InvokeAction("DescribeUHostKeyPairs", req, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, keyPair := range resp.KeyPairs {
+			if keyPair.KeyPairId == keyPairId {
+				return &keyPair, nil
+			}
+		}
+
+		if len(resp.KeyPairs) < 100 || offset+100 >= resp.TotalCount {
+			break
+		}
+	}
+
+	return nil, newNotFoundError(getNotFoundMessage("key pair", keyPairId))
+}
+
+func (client *UCloudClient) deleteKeyPair(keyPairId string) error {
+	conn := client.uhostgenericconn
+
+	req := &deleteUHostKeyPairsRequest{}
+	conn.SetupRequest(req)
+	req.KeyPairIds = []string{keyPairId}
+
+	var resp deleteUHostKeyPairsResponse
+	return conn.InvokeAction("DeleteUHostKeyPairs", req, &resp)
+}
+
+// createInstanceWithKeyPair will create instance which login by the key pair instead of password
+// describeInstancePrivateMac will return the mac address of the private ip of instance
+func (client *UCloudClient) describeInstancePrivateMac(instanceId string) (string, error) {
+	conn := client.uhostgenericconn
+
+	req := &describeUHostIPSetRequest{}
+	conn.SetupRequest(req)
+	req.UHostIds = []string{instanceId}
+
+	var resp describeUHostIPSetResponse
+	if err := conn.InvokeAction("DescribeUHostInstance", req, &resp); err != nil {
+		return "", err
+	}
+
+	if len(resp.UHostSet) < 1 {
+		return "", newNotFoundError(getNotFoundMessage("instance", instanceId))
+	}
+
+	for _, item := range resp.UHostSet[0].IPSet {
+		if item.Type == "Private" {
+			return item.Mac, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (client *UCloudClient) createInstanceWithKeyPair(req *uhost.CreateUHostInstanceRequest, keyPairId string) (*uhost.CreateUHostInstanceResponse, error) {
+	conn := client.uhostgenericconn
+
+	keyPairReq := &createUHostInstanceRequest{CreateUHostInstanceRequest: *req}
+	keyPairReq.LoginMode = ucloud.String("KeyPair")
+	keyPairReq.Password = nil
+	keyPairReq.KeyPairId = ucloud.String(keyPairId)
+
+	var resp uhost.CreateUHostInstanceResponse
+	if err := conn.InvokeAction("CreateUHostInstance", keyPairReq, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
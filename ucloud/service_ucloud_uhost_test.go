@@ -0,0 +1,65 @@
+package ucloud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ucloud/ucloud-sdk-go/ucloud"
+)
+
+func Test_createInstanceWithKeyPair(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Action": "CreateUHostInstanceResponse", "RetCode": 0, "UHostIds": ["uhost-test"]}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		PublicKey:  "pub",
+		PrivateKey: "pri",
+		Region:     "cn-bj2",
+		ProjectId:  "org-test",
+		BaseUrl:    server.URL,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	req := client.uhostconn.NewCreateUHostInstanceRequest()
+	req.Zone = ucloud.String("cn-bj2-02")
+	req.ImageId = ucloud.String("uimage-test")
+	req.Password = ucloud.String("wA1234567")
+
+	resp, err := client.createInstanceWithKeyPair(req, "uhostkp-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.UHostIds) != 1 || resp.UHostIds[0] != "uhost-test" {
+		t.Errorf("UHostIds = %v, want %v", resp.UHostIds, []string{"uhost-test"})
+	}
+
+	// the key pair is sent by its id instead of the public key, and the password is never sent
+	for k, want := range map[string]string{
+		"Action":    "CreateUHostInstance",
+		"LoginMode": "KeyPair",
+		"KeyPairId": "uhostkp-test",
+		"Zone":      "cn-bj2-02",
+		"ImageId":   "uimage-test",
+	} {
+		if got := query.Get(k); got != want {
+			t.Errorf("parameter %s = %q, want %q", k, got, want)
+		}
+	}
+
+	for _, k := range []string{"Password", "KeyPair"} {
+		if _, ok := query[k]; ok {
+			t.Errorf("parameter %s should not be sent, got %q", k, query.Get(k))
+		}
+	}
+}
@@ -3,7 +3,6 @@ package ucloud
 import (
 	"github.com/ucloud/ucloud-sdk-go/services/ulb"
 	"github.com/ucloud/ucloud-sdk-go/ucloud"
-	uerr "github.com/ucloud/ucloud-sdk-go/ucloud/error"
 )
 
 func (client *UCloudClient) describeLBById(lbId string) (*ulb.ULBSet, error) {
@@ -13,10 +12,8 @@ func (client *UCloudClient) describeLBById(lbId string) (*ulb.ULBSet, error) {
 
 	resp, err := conn.DescribeULB(req)
 
-	// [API-STYLE] lb api has not found err code, but others don't have
-	// TODO: don't use magic number
 	if err != nil {
-		if uErr, ok := err.(uerr.Error); ok && (uErr.Code() == 4103 || uErr.Code() == 4086) {
+		if isNotFoundError(err) {
 			return nil, newNotFoundError(getNotFoundMessage("lb", lbId))
 		}
 		return nil, err
@@ -37,10 +34,8 @@ func (client *UCloudClient) describeVServerById(lbId, listenerId string) (*ulb.U
 
 	resp, err := conn.DescribeVServer(req)
 
-	// [API-STYLE] vserver api has not found err code, but others don't have
-	// TODO: don't use magic number
 	if err != nil {
-		if uErr, ok := err.(uerr.Error); ok && uErr.Code() == 4103 {
+		if isNotFoundError(err) {
 			return nil, newNotFoundError(getNotFoundMessage("listener", listenerId))
 		}
 		return nil, err
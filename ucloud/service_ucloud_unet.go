@@ -3,7 +3,6 @@ package ucloud
 import (
 	"github.com/ucloud/ucloud-sdk-go/services/unet"
 	"github.com/ucloud/ucloud-sdk-go/ucloud"
-	uerr "github.com/ucloud/ucloud-sdk-go/ucloud/error"
 )
 
 func (c *UCloudClient) describeEIPById(eipId string) (*unet.UnetEIPSet, error) {
@@ -57,10 +56,8 @@ func (c *UCloudClient) describeFirewallById(sgId string) (*unet.FirewallDataSet,
 
 	resp, err := conn.DescribeFirewall(req)
 
-	// [API-STYLE] Fire wall api has not found err code, but others don't have
-	// TODO: don't use magic number
 	if err != nil {
-		if uErr, ok := err.(uerr.Error); ok && uErr.Code() == 54002 {
+		if isNotFoundError(err) {
 			return nil, newNotFoundError(getNotFoundMessage("security group", sgId))
 		}
 		return nil, err
@@ -72,3 +69,23 @@ func (c *UCloudClient) describeFirewallById(sgId string) (*unet.FirewallDataSet,
 
 	return &resp.DataSet[0], nil
 }
+
+// describeFirewallByResource will return the security group which is bound to the resource, such as the uhost instance
+func (c *UCloudClient) describeFirewallByResource(resourceType, resourceId string) (*unet.FirewallDataSet, error) {
+	conn := c.unetconn
+
+	req := conn.NewDescribeFirewallRequest()
+	req.ResourceType = ucloud.String(resourceType)
+	req.ResourceId = ucloud.String(resourceId)
+
+	resp, err := conn.DescribeFirewall(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.DataSet) < 1 {
+		return nil, newNotFoundError(getNotFoundMessage("security group of "+resourceType, resourceId))
+	}
+
+	return &resp.DataSet[0], nil
+}
@@ -3,7 +3,6 @@ package ucloud
 import (
 	"github.com/ucloud/ucloud-sdk-go/services/vpc"
 	"github.com/ucloud/ucloud-sdk-go/ucloud"
-	uerr "github.com/ucloud/ucloud-sdk-go/ucloud/error"
 )
 
 func (c *UCloudClient) describeVPCById(vpcId string) (*vpc.VPCInfo, error) {
@@ -52,7 +51,7 @@ func (c *UCloudClient) describeVPCIntercomById(vpcId, peerVPCId, peerRegion, pee
 
 	resp, err := conn.DescribeVPCIntercom(req)
 	if err != nil {
-		if uErr, ok := err.(uerr.Error); ok && uErr.Code() == 58103 {
+		if isNotFoundError(err) {
 			return nil, newNotFoundError(getNotFoundMessage("vpc peer connection", vpcId))
 		}
 		return nil, err
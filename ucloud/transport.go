@@ -0,0 +1,166 @@
+package ucloud
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// transportRouter is used to route the requests of sdk to the transport of provider instance.
+// The sdk always sends request by a new http.Client with http.DefaultTransport,
+// so that the router is installed as http.DefaultTransport,
+// and each provider instance is registered with a unique route token,
+// the token is appended to the User-Agent of its sdk clients and removed by the router before sending.
+type transportRouter struct {
+	mu     sync.RWMutex
+	next   http.RoundTripper
+	nextId int
+	routes map[string]http.RoundTripper
+}
+
+// transportRouteTokenPrefix is the prefix of product token in User-Agent to route the request
+const transportRouteTokenPrefix = "terraform-provider-ucloud-route/"
+
+var (
+	defaultTransportRouter     *transportRouter
+	defaultTransportRouterOnce sync.Once
+)
+
+// getTransportRouter will install the router as http.DefaultTransport at the first call.
+// NOTE: it replaces the process-global http.DefaultTransport, so that it affects every http client
+// of the plugin process without its own transport, the request without route token is sent by
+// the original http.DefaultTransport as before.
+func getTransportRouter() *transportRouter {
+	defaultTransportRouterOnce.Do(func() {
+		defaultTransportRouter = &transportRouter{
+			next:   http.DefaultTransport,
+			routes: make(map[string]http.RoundTripper),
+		}
+		http.DefaultTransport = defaultTransportRouter
+	})
+	return defaultTransportRouter
+}
+
+// Register will return a new route token of the transport,
+// the provider instances never share the transport even if they have the same credential.
+func (r *transportRouter) Register(transport http.RoundTripper) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextId++
+	token := fmt.Sprintf("%s%d", transportRouteTokenPrefix, r.nextId)
+	r.routes[token] = transport
+	return token
+}
+
+// Deregister will remove the route of token, it should be called when the provider instance is reconfigured
+func (r *transportRouter) Deregister(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.routes, token)
+}
+
+func (r *transportRouter) RoundTrip(req *http.Request) (*http.Response, error) {
+	userAgent := req.Header.Get("User-Agent")
+	token, rest := splitTransportRouteToken(userAgent)
+
+	r.mu.RLock()
+	transport, ok := r.routes[token]
+	r.mu.RUnlock()
+
+	if !ok {
+		return r.next.RoundTrip(req)
+	}
+
+	// the request should not be modified by transport, so that the token is removed from a copy of it
+	routed := new(http.Request)
+	*routed = *req
+	routed.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		routed.Header[k] = append([]string(nil), v...)
+	}
+	routed.Header.Set("User-Agent", rest)
+
+	return transport.RoundTrip(routed)
+}
+
+// splitTransportRouteToken will split the route token and the rest of User-Agent
+func splitTransportRouteToken(userAgent string) (string, string) {
+	fields := strings.Fields(userAgent)
+	for i, field := range fields {
+		if strings.HasPrefix(field, transportRouteTokenPrefix) {
+			rest := append(append([]string(nil), fields[:i]...), fields[i+1:]...)
+			return field, strings.Join(rest, " ")
+		}
+	}
+	return "", userAgent
+}
+
+// newHTTPTransport will build the transport shared by all of the product connections of provider,
+// the connections will be kept alive and reused between requests.
+func newHTTPTransport(c *Config) (*http.Transport, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if c.Proxy != "" {
+		proxyURL, err := url.Parse(c.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("%q is invalid, %s", "proxy", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.SkipTLSVerify,
+	}
+
+	if c.CABundle != "" {
+		pool, err := loadCABundle(c.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// loadCABundle will load the PEM encoded certificates of file and the system certificates into a pool
+func loadCABundle(filePath string) (*x509.CertPool, error) {
+	absPath, err := getAbsPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	pem, err := ioutil.ReadFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ca bundle %s, %s", filePath, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("cannot load any PEM encoded certificate from ca bundle %s", filePath)
+	}
+	return pool, nil
+}
@@ -0,0 +1,141 @@
+package ucloud
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_transportRouter(t *testing.T) {
+	var got []string
+	record := func(name string) http.RoundTripper {
+		return transportFunc(func(req *http.Request) (*http.Response, error) {
+			got = append(got, name+": "+req.Header.Get("User-Agent"))
+			return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+		})
+	}
+
+	router := &transportRouter{next: record("default"), routes: make(map[string]http.RoundTripper)}
+
+	// the provider aliases with the same credential are routed separately
+	foo := router.Register(record("foo"))
+	bar := router.Register(record("bar"))
+	if foo == bar {
+		t.Fatalf("route token should be unique, got %s", foo)
+	}
+
+	for _, userAgent := range []string{
+		"GO-SDK/0.1.0 Terraform/0.11.8 " + foo,
+		"GO-SDK/0.1.0 Terraform/0.11.8 " + bar,
+		"GO-SDK/0.1.0 Terraform/0.11.8",
+	} {
+		req, _ := http.NewRequest("GET", "https://api.ucloud.cn/?PublicKey=abc", nil)
+		req.Header.Set("User-Agent", userAgent)
+		if _, err := router.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+		if req.Header.Get("User-Agent") != userAgent {
+			t.Errorf("the original request should not be modified, got %q", req.Header.Get("User-Agent"))
+		}
+	}
+
+	want := []string{
+		"foo: GO-SDK/0.1.0 Terraform/0.11.8",
+		"bar: GO-SDK/0.1.0 Terraform/0.11.8",
+		"default: GO-SDK/0.1.0 Terraform/0.11.8",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("routed = %v, want %v", got, want)
+	}
+
+	// the route is removed after the provider instance is reconfigured
+	router.Deregister(foo)
+	if _, ok := router.routes[foo]; ok || len(router.routes) != 1 {
+		t.Errorf("route %s should be removed, got %v", foo, router.routes)
+	}
+}
+
+type transportFunc func(*http.Request) (*http.Response, error)
+
+func (f transportFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_newHTTPTransport(t *testing.T) {
+	transport, err := newHTTPTransport(&Config{Proxy: "http://proxy.internal:3128", SkipTLSVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest("GET", "https://api.ucloud.cn", nil)
+	proxyURL, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proxyURL == nil || proxyURL.Host != "proxy.internal:3128" {
+		t.Errorf("proxy = %v, want %v", proxyURL, "proxy.internal:3128")
+	}
+
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("InsecureSkipVerify should be true")
+	}
+}
+
+func Test_newHTTPTransport_caBundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	tempdir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempdir)
+
+	// the certificate of test server is only trusted by the ca bundle
+	caBundle := filepath.Join(tempdir, "ca.pem")
+	if err := ioutil.WriteFile(caBundle, certToPEM(server.Certificate().Raw), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"untrusted", Config{}, true},
+		{"ca bundle", Config{CABundle: caBundle}, false},
+		{"skip verify", Config{SkipTLSVerify: true}, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := newHTTPTransport(&tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := &http.Client{Transport: transport}
+			resp, err := client.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("request error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := newHTTPTransport(&Config{CABundle: filepath.Join(tempdir, "notfound.pem")}); err == nil {
+		t.Errorf("the missing ca bundle should raise error")
+	}
+}
+
+func certToPEM(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -2,6 +2,7 @@ package ucloud
 
 import (
 	"fmt"
+	"math"
 	"net"
 	"regexp"
 	"strconv"
@@ -88,6 +89,25 @@ type instanceType struct {
 	Memory        int
 	HostType      string
 	HostScaleType string
+	GPU           int
+}
+
+// instanceHostFamily is the machine family of instance type, the family is the prefix of instance type, such as "n" of "n-highcpu-1"
+type instanceHostFamily struct {
+	// UHostType is the type of uhost, it is empty as the default type of availability zone
+	UHostType string
+	MaxCPU    int
+	// MaxGPU is the max count of gpu, it is 0 if gpu is not supported
+	MaxGPU int
+}
+
+var instanceHostFamilies = map[string]instanceHostFamily{
+	"n":  {"", 32, 0},
+	"c":  {"C1", 32, 0},
+	"o":  {"O", 64, 0},
+	"g1": {"G1", 32, 2},
+	"g2": {"G2", 32, 4},
+	"g3": {"G3", 32, 4},
 }
 
 func parseInstanceType(s string) (*instanceType, error) {
@@ -96,25 +116,71 @@ func parseInstanceType(s string) (*instanceType, error) {
 		return nil, fmt.Errorf("instance type is invalid, got %s", s)
 	}
 
+	hostType := splited[0]
+	err := checkStringIn(hostType, availableHostTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	// the count of gpu is the last part of instance type, such as "g2-standard-8-2"
+	gpu := 0
+	if family := instanceHostFamilies[hostType]; family.MaxGPU > 0 {
+		gpu, err = strconv.Atoi(splited[len(splited)-1])
+		if err != nil {
+			return nil, fmt.Errorf("gpu count is invalid, expected like %s-standard-8-1", hostType)
+		}
+
+		if gpu < 1 || family.MaxGPU < gpu {
+			return nil, fmt.Errorf("gpu count is invalid, it must between 1 ~ %v", family.MaxGPU)
+		}
+
+		splited = splited[:len(splited)-1]
+	}
+
+	var t *instanceType
 	if splited[1] == "customized" {
-		return parseInstanceTypeByCustomize(splited...)
+		t, err = parseInstanceTypeByCustomize(splited...)
+	} else {
+		t, err = parseInstanceTypeByNormal(splited...)
+	}
+
+	if err != nil {
+		return nil, err
 	}
 
-	return parseInstanceTypeByNormal(splited...)
+	t.GPU = gpu
+	return t, nil
 }
 
 func (i *instanceType) String() string {
+	var s string
 	if i.Iscustomized() {
-		return fmt.Sprintf("%s-%s-%v-%v", i.HostType, i.HostScaleType, i.CPU, i.Memory)
+		// the memory is formatted without truncating, such as "1.5" for 1536 MB, so that it is the same after parsed again
+		s = fmt.Sprintf("%s-%s-%v-%s", i.HostType, i.HostScaleType, i.CPU, strconv.FormatFloat(float64(i.Memory)/1024, 'f', -1, 64))
 	} else {
-		return fmt.Sprintf("%s-%s-%v", i.HostType, i.HostScaleType, i.CPU)
+		s = fmt.Sprintf("%s-%s-%v", i.HostType, i.HostScaleType, i.CPU)
+	}
+
+	if i.GPU > 0 {
+		s = fmt.Sprintf("%s-%v", s, i.GPU)
 	}
+	return s
 }
 
 func (i *instanceType) Iscustomized() bool {
 	return i.HostScaleType == "customized"
 }
 
+// Equal will return true if the instance types are the same machine, such as "n-customized-1-2" and "n-basic-1"
+func (i *instanceType) Equal(o *instanceType) bool {
+	return i.HostType == o.HostType && i.CPU == o.CPU && i.Memory == o.Memory && i.GPU == o.GPU
+}
+
+// UHostType will return the type of uhost by the machine family, it is empty as the default type of availability zone
+func (i *instanceType) UHostType() string {
+	return instanceHostFamilies[i.HostType].UHostType
+}
+
 var instanceTypeScaleMap = map[string]int{
 	"highcpu":  1 * 1024,
 	"basic":    2 * 1024,
@@ -122,7 +188,25 @@ var instanceTypeScaleMap = map[string]int{
 	"highmem":  8 * 1024,
 }
 
-var availableHostTypes = []string{"n"}
+// newInstanceTypeByUHost will build the instance type by the attributes of uhost, the standard type is preferred to the customized one,
+// the machine family is "n" if the type of uhost is not a known one, such as "N1" and "N2".
+func newInstanceTypeByUHost(cpu, memory int, uhostType string, gpu int) *instanceType {
+	t := &instanceType{CPU: cpu, Memory: memory, HostType: "n", HostScaleType: "customized", GPU: gpu}
+	for hostType, family := range instanceHostFamilies {
+		if family.UHostType != "" && family.UHostType == uhostType {
+			t.HostType = hostType
+		}
+	}
+
+	for hostScaleType, scale := range instanceTypeScaleMap {
+		if memory == cpu*scale {
+			t.HostScaleType = hostScaleType
+		}
+	}
+	return t
+}
+
+var availableHostTypes = []string{"n", "c", "o", "g1", "g2", "g3"}
 
 func parseInstanceTypeByCustomize(splited ...string) (*instanceType, error) {
 	if len(splited) != 4 {
@@ -142,16 +226,22 @@ func parseInstanceTypeByCustomize(splited ...string) (*instanceType, error) {
 		return nil, fmt.Errorf("cpu count is invalid, please use a number")
 	}
 
-	if cpu < 1 || 32 < cpu {
-		return nil, fmt.Errorf("cpu count is invalid, it must between 1 ~ 32")
+	if maxCPU := instanceHostFamilies[hostType].MaxCPU; cpu < 1 || maxCPU < cpu {
+		return nil, fmt.Errorf("cpu count is invalid, it must between 1 ~ %v", maxCPU)
 	}
 
-	memory, err := strconv.Atoi(splited[3])
+	// the memory is measured in GB, it may be a fraction which is a whole number of MB, such as "1.5"
+	memoryGB, err := strconv.ParseFloat(splited[3], 64)
 	if err != nil {
 		return nil, fmt.Errorf("memory count is invalid, please use a number")
 	}
 
-	if memory < 1 || 256 < memory {
+	memory := memoryGB * 1024
+	if memory != math.Trunc(memory) {
+		return nil, fmt.Errorf("memory count is invalid, it must be a whole number of MB, got %s GB", splited[3])
+	}
+
+	if memoryGB < 1 || 256 < memoryGB {
 		return nil, fmt.Errorf("memory count is invalid, it must between 1 ~ 128")
 	}
 
@@ -159,7 +249,7 @@ func parseInstanceTypeByCustomize(splited ...string) (*instanceType, error) {
 	t.HostType = hostType
 	t.HostScaleType = hostScaleType
 	t.CPU = cpu
-	t.Memory = memory * 1024
+	t.Memory = int(memory)
 	return t, nil
 }
 
@@ -183,8 +273,8 @@ func parseInstanceTypeByNormal(splited ...string) (*instanceType, error) {
 			return nil, fmt.Errorf("cpu count is invalid, please use a number")
 		}
 
-		if cpu < 1 || 32 < cpu {
-			return nil, fmt.Errorf("cpu count is invalid, it must between 1 ~ 32")
+		if maxCPU := instanceHostFamilies[hostType].MaxCPU; cpu < 1 || maxCPU < cpu {
+			return nil, fmt.Errorf("cpu count is invalid, it must between 1 ~ %v", maxCPU)
 		}
 
 		memory := cpu * scale
@@ -16,11 +16,15 @@ func Test_parseInstanceType(t *testing.T) {
 		want    *instanceType
 		wantErr bool
 	}{
-		{"ok_highcpu", args{"n-highcpu-1"}, &instanceType{1, 1024, "n", "highcpu"}, false},
-		{"ok_basic", args{"n-basic-1"}, &instanceType{1, 2048, "n", "basic"}, false},
-		{"ok_standard", args{"n-standard-1"}, &instanceType{1, 4096, "n", "standard"}, false},
-		{"ok_highmem", args{"n-highmem-1"}, &instanceType{1, 8192, "n", "highmem"}, false},
-		{"ok_customized", args{"n-customized-1-1"}, &instanceType{1, 1024, "n", "customized"}, false},
+		{"ok_highcpu", args{"n-highcpu-1"}, &instanceType{1, 1024, "n", "highcpu", 0}, false},
+		{"ok_basic", args{"n-basic-1"}, &instanceType{1, 2048, "n", "basic", 0}, false},
+		{"ok_standard", args{"n-standard-1"}, &instanceType{1, 4096, "n", "standard", 0}, false},
+		{"ok_highmem", args{"n-highmem-1"}, &instanceType{1, 8192, "n", "highmem", 0}, false},
+		{"ok_customized", args{"n-customized-1-1"}, &instanceType{1, 1024, "n", "customized", 0}, false},
+		{"ok_high_frequency", args{"c-standard-2"}, &instanceType{2, 8192, "c", "standard", 0}, false},
+		{"ok_outstanding", args{"o-highmem-64"}, &instanceType{64, 524288, "o", "highmem", 0}, false},
+		{"ok_gpu", args{"g2-standard-8-2"}, &instanceType{8, 32768, "g2", "standard", 2}, false},
+		{"ok_gpu_customized", args{"g1-customized-4-16-1"}, &instanceType{4, 16384, "g1", "customized", 1}, false},
 
 		{"err_type", args{"nx-highcpu-1"}, nil, true},
 		{"err_scale_type", args{"n-invalid-1"}, nil, true},
@@ -29,6 +33,10 @@ func Test_parseInstanceType(t *testing.T) {
 		{"err_cpu_is_invalid", args{"n-highcpu-x"}, nil, true},
 		{"err_customized_format_len", args{"n-customized-1"}, nil, true},
 		{"err_customized_format_number", args{"n-customized-x"}, nil, true},
+		{"err_gpu_missing", args{"g2-standard-8"}, nil, true},
+		{"err_gpu_too_much", args{"g1-standard-8-4"}, nil, true},
+		{"err_gpu_not_supported", args{"n-standard-8-1"}, nil, true},
+		{"err_cpu_too_much_of_family", args{"c-highcpu-33"}, nil, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -45,13 +53,75 @@ func Test_parseInstanceType(t *testing.T) {
 			if !(tt.want.CPU == got.CPU) ||
 				!(tt.want.Memory == got.Memory) ||
 				!(tt.want.HostType == got.HostType) ||
-				!(tt.want.HostScaleType == got.HostScaleType) {
+				!(tt.want.HostScaleType == got.HostScaleType) ||
+				!(tt.want.GPU == got.GPU) {
 				t.Errorf("parseInstanceType() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func Test_instanceType_String(t *testing.T) {
+	for _, s := range []string{"n-highcpu-1", "n-customized-2-4", "n-customized-1-1.5", "o-standard-16", "g3-highmem-8-4", "g2-customized-8-32-1"} {
+		got, err := parseInstanceType(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.String() != s {
+			t.Errorf("String() = %v, want %v", got.String(), s)
+		}
+	}
+}
+
+func Test_instanceType_String_roundTrip(t *testing.T) {
+	// the memory of customized type from api may be not a whole number of GB
+	for _, memory := range []int{1024, 1536, 2560, 3000} {
+		want := newInstanceTypeByUHost(1, memory, "", 0)
+		got, err := parseInstanceType(want.String())
+		if err != nil {
+			t.Fatalf("parse %s failed, %s", want.String(), err)
+		}
+
+		if !got.Equal(want) {
+			t.Errorf("memory of %s = %d, want %d", want.String(), got.Memory, memory)
+		}
+	}
+
+	if _, err := parseInstanceType("n-customized-1-1.0001"); err == nil {
+		t.Errorf("the memory which is not a whole number of MB should be invalid")
+	}
+}
+
+func Test_newInstanceTypeByUHost(t *testing.T) {
+	tests := []struct {
+		name      string
+		cpu       int
+		memory    int
+		uhostType string
+		gpu       int
+		want      string
+	}{
+		{"standard", 2, 8192, "N2", 0, "n-standard-2"},
+		{"customized", 2, 6144, "N1", 0, "n-customized-2-6"},
+		{"high_frequency", 4, 4096, "C1", 0, "c-highcpu-4"},
+		{"gpu", 8, 65536, "G3", 2, "g3-highmem-8-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newInstanceTypeByUHost(tt.cpu, tt.memory, tt.uhostType, tt.gpu)
+			if got.String() != tt.want {
+				t.Errorf("newInstanceTypeByUHost() = %v, want %v", got.String(), tt.want)
+			}
+
+			want, _ := parseInstanceType(tt.want)
+			if !got.Equal(want) {
+				t.Errorf("newInstanceTypeByUHost() = %#v, should be equal to %#v", got, want)
+			}
+		})
+	}
+}
+
 func Test_parseUCloudCidrBlock(t *testing.T) {
 	type args struct {
 		s string
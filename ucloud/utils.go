@@ -2,6 +2,8 @@ package ucloud
 
 import (
 	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -36,6 +38,12 @@ func hashStringArray(arr []string) string {
 	return fmt.Sprintf("%d", hashcode.String(buf.String()))
 }
 
+// userDataHashSum will return the sha1 hash of user data, the hash is stored in state instead of the raw content
+func userDataHashSum(userData string) string {
+	sum := sha1.Sum([]byte(userData))
+	return hex.EncodeToString(sum[:])
+}
+
 func getAbsPath(filePath string) (string, error) {
 	if strings.HasPrefix(filePath, "~") {
 		usr, err := user.Current()
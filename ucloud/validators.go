@@ -1,8 +1,10 @@
 package ucloud
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -274,3 +276,107 @@ func validateDBInstanceBlackList(v interface{}, k string) (ws []string, errors [
 
 	return
 }
+
+// validateEndpointURL is used to validate the endpoint of api, only http and https are supported
+func validateEndpointURL(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		errors = append(errors, fmt.Errorf("%q is invalid, should be an url with http or https scheme, got %q", k, value))
+	}
+
+	return
+}
+
+// validateProxyURL is used to validate the url of proxy, http, https and socks5 are supported
+func validateProxyURL(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") {
+		errors = append(errors, fmt.Errorf("%q is invalid, should be an url with http, https or socks5 scheme, got %q", k, value))
+	}
+
+	return
+}
+
+var keyPairNamePattern = regexp.MustCompile(`^[A-Za-z0-9\p{Han}-_.]{1,63}$`)
+
+func validateKeyPairName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if !keyPairNamePattern.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q is invalid, should have 1 - 63 characters and only support chinese, english, numbers, '-', '_', '.', got %q", k, value))
+	}
+
+	return
+}
+
+var keyPairPublicKeyTypes = []string{"ssh-rsa", "ssh-dss", "ssh-ed25519", "ecdsa-sha2-nistp256", "ecdsa-sha2-nistp384", "ecdsa-sha2-nistp521"}
+
+// validateKeyPairPublicKey is used to validate the public key in OpenSSH authorized_keys format, such as "ssh-rsa AAAA... comment"
+func validateKeyPairPublicKey(v interface{}, k string) (ws []string, errors []error) {
+	fields := strings.Fields(v.(string))
+
+	if len(fields) < 2 || checkStringIn(fields[0], keyPairPublicKeyTypes) != nil {
+		errors = append(errors, fmt.Errorf("%q is invalid, should be in OpenSSH format and the type should be one of %s", k, strings.Join(keyPairPublicKeyTypes, ", ")))
+		return
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(fields[1]); err != nil {
+		errors = append(errors, fmt.Errorf("%q is invalid, the key body should be encoded by base64, %s", k, err))
+	}
+
+	return
+}
+
+func validateUserData(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if size := base64.StdEncoding.EncodedLen(len(value)); size > maxUserDataSize {
+		errors = append(errors, fmt.Errorf("%q is invalid, should not be larger than %d bytes after encoded by base64, got %d bytes", k, maxUserDataSize, size))
+	}
+
+	return
+}
+
+func validateUserDataBase64(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q is invalid, should be encoded by base64, %s", k, err))
+	}
+
+	if len(value) > maxUserDataSize {
+		errors = append(errors, fmt.Errorf("%q is invalid, should not be larger than %d bytes, got %d bytes", k, maxUserDataSize, len(value)))
+	}
+
+	return
+}
+
+func validateIPv4Address(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+		errors = append(errors, fmt.Errorf("%q is invalid, should be an ipv4 address, got %q", k, value))
+	}
+
+	return
+}
+
+func validateMACAddress(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if _, err := net.ParseMAC(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q is invalid, should be a mac address like xx:xx:xx:xx:xx:xx, got %q", k, value))
+	}
+
+	return
+}